@@ -6,6 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/elangreza14/grpc-quiz/internal/usecase"
 	quiz "github.com/elangreza14/grpc-quiz/proto"
@@ -19,15 +22,21 @@ import (
 // Client is ...
 type Client struct {
 	player   string
+	room     string
+	team     string
 	client   quiz.QuizClient
 	Terminal *usecase.Terminal
+	// Input is optional, the answer is read from Input instead of the terminal
+	Input <-chan string
 }
 
 // NewClient is ...
-func NewClient(player string) *Client {
+func NewClient(player, room, team string) *Client {
 	return &Client{
 		player:   player,
-		Terminal: usecase.NewTerminal(),
+		room:     room,
+		team:     team,
+		Terminal: usecase.NewTerminal(os.Stdin),
 	}
 }
 
@@ -50,6 +59,8 @@ func (c *Client) Start(ctx context.Context) error {
 func (c *Client) register(ctx context.Context) error {
 	res, err := c.client.Register(ctx, &quiz.RegisterRequest{
 		Player: c.player,
+		Room:   c.room,
+		Team:   c.team,
 	})
 	if err != nil {
 		return err
@@ -61,7 +72,11 @@ func (c *Client) register(ctx context.Context) error {
 }
 
 func (c *Client) stream(ctx context.Context) error {
-	md := metadata.New(map[string]string{"player": c.player})
+	// the sender is stopped together with the stream
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	md := metadata.New(map[string]string{"player": c.player, "room": c.room})
 	ctx = metadata.NewOutgoingContext(ctx, md)
 
 	streamer, err := c.client.Stream(ctx)
@@ -91,23 +106,89 @@ func (c *Client) streamReceive(streamer quiz.Quiz_StreamClient) error {
 			return err
 		}
 
-		switch res.Event.(type) {
-		case *quiz.StreamResponse_ServerAnnouncement:
-			fmt.Println(res.GetServerAnnouncement().Message)
-		case *quiz.StreamResponse_ServerShutdown:
-			fmt.Println("server shuting down")
+		if done := printEvent(res); done {
 			return nil
 		}
 	}
 }
 
+// printEvent print the event from server, return true when the server is shutting down
+func printEvent(res *quiz.StreamResponse) bool {
+	switch res.Event.(type) {
+	case *quiz.StreamResponse_ServerAnnouncement:
+		fmt.Println(res.GetServerAnnouncement().Message)
+	case *quiz.StreamResponse_Question:
+		question := res.GetQuestion()
+		if question.SuddenDeath {
+			fmt.Println("sudden death!")
+		}
+		fmt.Printf("round %d (%s): %s\n", question.Round, question.Difficulty, question.Question)
+		fmt.Printf("%d points, answer before %s (%ds)\n", question.Points,
+			question.Deadline.AsTime().Local().Format(time.TimeOnly), question.TimeLimit)
+		for _, option := range question.Options {
+			fmt.Printf("  %s. %s\n", option.Key, option.Text)
+		}
+		if question.Hints > 0 {
+			fmt.Printf("%d hint(s) available, type /hint for the next hint\n", question.Hints)
+		}
+	case *quiz.StreamResponse_Paused:
+		paused := res.GetPaused()
+		fmt.Printf("round %d is paused by the host, %s left\n", paused.Round, paused.Remaining.AsDuration().Round(time.Second))
+	case *quiz.StreamResponse_Resumed:
+		resumed := res.GetResumed()
+		if extended := resumed.Extended.AsDuration(); extended > 0 {
+			fmt.Printf("the host added %s to round %d, answer before %s\n", extended, resumed.Round, resumed.Deadline.AsTime().Local().Format(time.TimeOnly))
+		} else {
+			fmt.Printf("round %d is resumed, answer before %s\n", resumed.Round, resumed.Deadline.AsTime().Local().Format(time.TimeOnly))
+		}
+	case *quiz.StreamResponse_Review:
+		review := res.GetReview()
+		fmt.Printf("review of round %d/%d, waiting for the host to start the next round\n", review.Round, review.TotalRound)
+	case *quiz.StreamResponse_Buzzer:
+		if len(res.GetBuzzer().LockedOut) > 0 {
+			fmt.Printf("locked out: %s\n", strings.Join(res.GetBuzzer().LockedOut, ", "))
+		}
+	case *quiz.StreamResponse_RoundResult:
+		result := res.GetRoundResult()
+		fmt.Printf("round %d answer: %s\n", result.Round, result.Answer)
+		for _, option := range result.Distribution {
+			fmt.Printf("  %s: %d\n", option.Option, option.Total)
+		}
+	case *quiz.StreamResponse_Leaderboard:
+		leaderboard := res.GetLeaderboard()
+		if leaderboard.Final {
+			fmt.Println("=== final point ===")
+		} else {
+			fmt.Println("=== current point ===")
+		}
+		for _, score := range leaderboard.Scores {
+			tag := ""
+			if score.Bot {
+				tag = " (bot)"
+			}
+			if score.Eliminated {
+				tag += " (eliminated)"
+			}
+			fmt.Printf("player: %v point %v%s\n", score.Player, score.Point, tag)
+		}
+		for _, team := range leaderboard.Teams {
+			fmt.Printf("team: %v point %.2f\n", team.Team, team.Point)
+		}
+	case *quiz.StreamResponse_ServerShutdown:
+		fmt.Println("server shuting down")
+		return true
+	}
+
+	return false
+}
+
 func (c *Client) streamSend(streamer quiz.Quiz_StreamClient) {
 	for {
 		select {
 		case <-streamer.Context().Done():
 			return
 		default:
-			val, ok := c.Terminal.ValText()
+			val, ok := c.input(streamer.Context())
 			if ok {
 				message := &quiz.Message{Message: val}
 				if s, ok := status.FromError(streamer.Send(message)); ok {
@@ -120,3 +201,17 @@ func (c *Client) streamSend(streamer quiz.Quiz_StreamClient) {
 		}
 	}
 }
+
+// input read from Input when it is set, otherwise from the terminal
+func (c *Client) input(ctx context.Context) (string, bool) {
+	if c.Input == nil {
+		return c.Terminal.ValText()
+	}
+
+	select {
+	case <-ctx.Done():
+		return "", false
+	case val, ok := <-c.Input:
+		return val, ok
+	}
+}
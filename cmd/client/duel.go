@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	quiz "github.com/elangreza14/grpc-quiz/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Duel find the opponent in the matchmaking queue then play in the duel room
+type Duel struct {
+	*Client
+	timeout time.Duration
+	bot     bool
+}
+
+// NewDuel is ...
+func NewDuel(player string, timeout time.Duration, bot bool) *Duel {
+	return &Duel{
+		Client:  NewClient(player, "", ""),
+		timeout: timeout,
+		bot:     bot,
+	}
+}
+
+// Start is ...
+func (d *Duel) Start(ctx context.Context) error {
+	conn, err := grpc.DialContext(ctx, ":50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+
+	d.client = quiz.NewQuizClient(conn)
+
+	fmt.Println("looking for an opponent...")
+	match, err := d.client.FindMatch(ctx, &quiz.FindMatchRequest{
+		Player:      d.player,
+		Timeout:     durationpb.New(d.timeout),
+		BotFallback: d.bot,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("matched with %s (%d), your rating is %d\n", match.Opponent, match.OpponentRating, match.Rating)
+	d.room = match.Room
+
+	if err = d.register(ctx); err != nil {
+		return err
+	}
+
+	return d.stream(ctx)
+}
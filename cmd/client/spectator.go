@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	quiz "github.com/elangreza14/grpc-quiz/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Spectator is read-only client, it receive the game but can't answer
+type Spectator struct {
+	name  string
+	room  string
+	delay time.Duration
+}
+
+// NewSpectator is ...
+func NewSpectator(name, room string, delay time.Duration) *Spectator {
+	return &Spectator{
+		name:  name,
+		room:  room,
+		delay: delay,
+	}
+}
+
+// Start is ...
+func (s *Spectator) Start(ctx context.Context) error {
+	conn, err := grpc.DialContext(ctx, ":50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	streamer, err := quiz.NewQuizClient(conn).Spectate(ctx, &quiz.SpectateRequest{
+		Room:  s.room,
+		Name:  s.name,
+		Delay: durationpb.New(s.delay),
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		res, err := streamer.Recv()
+		if err != nil {
+			return err
+		}
+
+		if done := printEvent(res); done {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,414 @@
+// Package gateway ....
+package gateway
+
+import (
+	"context"
+	_ "embed" // embed the openapi spec
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	server "github.com/elangreza14/grpc-quiz/cmd/server"
+	quiz "github.com/elangreza14/grpc-quiz/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+//go:embed quiz.swagger.json
+var spec []byte
+
+var (
+	marshaler   = protojson.MarshalOptions{EmitUnpopulated: true}
+	unmarshaler = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// Gateway is REST/JSON gateway in front of the grpc server
+type Gateway struct {
+	addr   string
+	server *server.Server
+}
+
+// NewGateway is ...
+func NewGateway(srv *server.Server, addr string) *Gateway {
+	return &Gateway{
+		addr:   addr,
+		server: srv,
+	}
+}
+
+// Start is ...
+func (g *Gateway) Start(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:              g.addr,
+		Handler:           g.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Printf("REST gateway listening on %s\n", g.addr)
+
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// Handler return the routes of the gateway
+func (g *Gateway) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/register", g.register)
+	mux.HandleFunc("/v1/rooms", g.rooms)
+	mux.HandleFunc("/v1/rooms/", g.room)
+	mux.HandleFunc("/v1/history", g.history)
+	mux.HandleFunc("/v1/match", g.match)
+	mux.HandleFunc("/v1/tournaments", g.tournaments)
+	mux.HandleFunc("/v1/tournaments/", g.tournament)
+	mux.HandleFunc("/v1/admin/bank", g.bank)
+	mux.HandleFunc("/v1/admin/bank/reload", g.reloadBank)
+	mux.HandleFunc("/openapi.json", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(spec)
+	})
+
+	return mux
+}
+
+// POST /v1/register
+func (g *Gateway) register(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, status.Error(codes.Unimplemented, "method not allowed"))
+		return
+	}
+
+	req := &quiz.RegisterRequest{}
+	if err := readBody(r, req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	res, err := g.server.Register(r.Context(), req)
+	writeResponse(w, res, err)
+}
+
+// GET /v1/rooms
+// POST /v1/rooms
+func (g *Gateway) rooms(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		res, err := g.server.ListRooms(r.Context(), &quiz.ListRoomsRequest{})
+		writeResponse(w, res, err)
+	case http.MethodPost:
+		req := &quiz.CreateRoomRequest{}
+		if err := readBody(r, req); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		res, err := g.server.CreateRoom(r.Context(), req)
+		writeResponse(w, res, err)
+	default:
+		writeError(w, status.Error(codes.Unimplemented, "method not allowed"))
+	}
+}
+
+// GET  /v1/rooms/{room}/state
+// POST /v1/rooms/{room}/start
+// GET  /v1/rooms/{room}/events
+// POST /v1/rooms/{room}/control
+// POST /v1/rooms/{room}/bots
+// GET  /v1/rooms/{room}/report?format={format}&host_key={key}
+func (g *Gateway) room(w http.ResponseWriter, r *http.Request) {
+	path := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/rooms/"), "/")
+	if len(path) != 2 || path[0] == "" {
+		writeError(w, status.Error(codes.NotFound, "route not found"))
+		return
+	}
+
+	req := &quiz.RoomRequest{Room: path[0]}
+
+	switch {
+	case path[1] == "state" && r.Method == http.MethodGet:
+		res, err := g.server.GetState(r.Context(), req)
+		writeResponse(w, res, err)
+	case path[1] == "start" && r.Method == http.MethodPost:
+		res, err := g.server.StartGame(r.Context(), req)
+		writeResponse(w, res, err)
+	case path[1] == "events" && r.Method == http.MethodGet:
+		g.events(w, r, req)
+	case path[1] == "control" && r.Method == http.MethodPost:
+		control := &quiz.ControlRoundRequest{}
+		if err := readBody(r, control); err != nil {
+			writeError(w, err)
+			return
+		}
+		control.Room = req.Room
+
+		res, err := g.server.Admin().ControlRound(r.Context(), control)
+		writeResponse(w, res, err)
+	case path[1] == "bots" && r.Method == http.MethodPost:
+		bots := &quiz.AddBotsRequest{}
+		if err := readBody(r, bots); err != nil {
+			writeError(w, err)
+			return
+		}
+		bots.Room = req.Room
+
+		res, err := g.server.Admin().AddBots(r.Context(), bots)
+		writeResponse(w, res, err)
+	case path[1] == "report" && r.Method == http.MethodGet:
+		res, err := g.server.Admin().ExportResult(r.Context(), &quiz.ExportResultRequest{
+			HostKey: r.URL.Query().Get("host_key"),
+			Room:    req.Room,
+			Format:  r.URL.Query().Get("format"),
+		})
+		writeResponse(w, res, err)
+	default:
+		writeError(w, status.Error(codes.NotFound, "route not found"))
+	}
+}
+
+// GET /v1/history?room={room}
+func (g *Gateway) history(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, status.Error(codes.Unimplemented, "method not allowed"))
+		return
+	}
+
+	res, err := g.server.GetHistory(r.Context(), &quiz.GetHistoryRequest{
+		Room: r.URL.Query().Get("room"),
+	})
+	writeResponse(w, res, err)
+}
+
+// POST /v1/match
+func (g *Gateway) match(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, status.Error(codes.Unimplemented, "method not allowed"))
+		return
+	}
+
+	req := &quiz.FindMatchRequest{}
+	if err := readBody(r, req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	res, err := g.server.FindMatch(r.Context(), req)
+	writeResponse(w, res, err)
+}
+
+// GET  /v1/tournaments
+// POST /v1/tournaments
+func (g *Gateway) tournaments(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		res, err := g.server.ListTournaments(r.Context(), &quiz.ListTournamentsRequest{})
+		writeResponse(w, res, err)
+	case http.MethodPost:
+		req := &quiz.CreateTournamentRequest{}
+		if err := readBody(r, req); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		res, err := g.server.CreateTournament(r.Context(), req)
+		writeResponse(w, res, err)
+	default:
+		writeError(w, status.Error(codes.Unimplemented, "method not allowed"))
+	}
+}
+
+// GET  /v1/tournaments/{tournament}/bracket
+// POST /v1/tournaments/{tournament}/start
+func (g *Gateway) tournament(w http.ResponseWriter, r *http.Request) {
+	path := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/tournaments/"), "/")
+	if len(path) != 2 || path[0] == "" {
+		writeError(w, status.Error(codes.NotFound, "route not found"))
+		return
+	}
+
+	req := &quiz.TournamentRequest{Tournament: path[0]}
+
+	switch {
+	case path[1] == "bracket" && r.Method == http.MethodGet:
+		res, err := g.server.GetBracket(r.Context(), req)
+		writeResponse(w, res, err)
+	case path[1] == "start" && r.Method == http.MethodPost:
+		res, err := g.server.StartTournament(r.Context(), req)
+		writeResponse(w, res, err)
+	default:
+		writeError(w, status.Error(codes.NotFound, "route not found"))
+	}
+}
+
+// GET /v1/admin/bank?host_key={key}
+func (g *Gateway) bank(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, status.Error(codes.Unimplemented, "method not allowed"))
+		return
+	}
+
+	res, err := g.server.Admin().GetBank(r.Context(), &quiz.AdminRequest{
+		HostKey: r.URL.Query().Get("host_key"),
+	})
+	writeResponse(w, res, err)
+}
+
+// POST /v1/admin/bank/reload
+func (g *Gateway) reloadBank(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, status.Error(codes.Unimplemented, "method not allowed"))
+		return
+	}
+
+	req := &quiz.AdminRequest{}
+	if err := readBody(r, req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	res, err := g.server.Admin().ReloadBank(r.Context(), req)
+	writeResponse(w, res, err)
+}
+
+// events mirror the StreamResponse of the room as Server-Sent Events
+func (g *Gateway) events(w http.ResponseWriter, r *http.Request, req *quiz.RoomRequest) {
+	room, ok := g.server.Lobby.GetRoom(req.Room)
+	if !ok {
+		writeError(w, status.Error(codes.NotFound, "room not found"))
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, status.Error(codes.Internal, "streaming is not supported"))
+		return
+	}
+
+	events, stop := g.server.Watch(r.Context(), room, 0)
+	defer stop()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case evt, ok := <-events:
+			if !ok {
+				return
+			}
+
+			body, err := marshaler.Marshal(evt)
+			if err != nil {
+				continue
+			}
+
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", eventName(evt), body)
+			flusher.Flush()
+
+			if evt.GetServerShutdown() != nil {
+				return
+			}
+		}
+	}
+}
+
+// eventName is the name of the oneof field which is set in the StreamResponse
+func eventName(evt *quiz.StreamResponse) string {
+	msg := evt.ProtoReflect()
+	field := msg.WhichOneof(msg.Descriptor().Oneofs().ByName("event"))
+	if field == nil {
+		return "message"
+	}
+
+	return string(field.Name())
+}
+
+func readBody(r *http.Request, req proto.Message) error {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if len(body) == 0 {
+		return nil
+	}
+
+	if err = unmarshaler.Unmarshal(body, req); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return nil
+}
+
+func writeResponse(w http.ResponseWriter, res proto.Message, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	body, err := marshaler.Marshal(res)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+// writeError write the error in the same shape as grpc-gateway
+func writeError(w http.ResponseWriter, err error) {
+	sts := status.Convert(err)
+	body, _ := marshaler.Marshal(sts.Proto())
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus(sts.Code()))
+	_, _ = w.Write(body)
+}
+
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,367 @@
+// Package loadtest ....
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/elangreza14/grpc-quiz/internal/usecase"
+	quiz "github.com/elangreza14/grpc-quiz/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// joinRetry is the delay before the stream is opened again, the player is inserted by the room after the register
+const joinRetry = 10 * time.Millisecond
+
+type (
+	// Config of the load test
+	Config struct {
+		Addr    string
+		Players int
+		Rooms   int
+		// Latency is the delay of the answer after the question is received
+		Latency  usecase.LatencyDist
+		Accuracy usecase.AccuracyRange
+		// Answers is the answer of the question in the server, the unknown question is answered randomly
+		Answers usecase.AnswerKey
+		// Timeout stop the players which is still playing
+		Timeout time.Duration
+		Seed    int64
+	}
+
+	// LoadTest simulate the players, every player has its own connection and play in one of the rooms
+	LoadTest struct {
+		cfg Config
+		out io.Writer
+
+		mu     sync.Mutex
+		joins  []time.Duration
+		events []time.Duration
+		errors map[codes.Code]int
+		// reasons is the first message of each error code
+		reasons map[codes.Code]string
+		answers int
+		correct int
+	}
+
+	// simPlayer is one simulated player
+	simPlayer struct {
+		name     string
+		room     string
+		accuracy float64
+		rand     *rand.Rand
+		joined   bool
+		finished bool
+		// received is the round event received by the player, e.g. question 1, it is used for counting the dropped message
+		received map[string]bool
+	}
+)
+
+// NewLoadTest is ...
+func NewLoadTest(cfg Config) *LoadTest {
+	if cfg.Addr == "" {
+		cfg.Addr = ":50051"
+	}
+
+	if cfg.Rooms <= 0 {
+		cfg.Rooms = 1
+	}
+
+	if cfg.Seed == 0 {
+		cfg.Seed = time.Now().UnixNano()
+	}
+
+	return &LoadTest{
+		cfg:     cfg,
+		out:     os.Stdout,
+		errors:  map[codes.Code]int{},
+		reasons: map[codes.Code]string{},
+	}
+}
+
+// Start create the rooms, join the players, start the games and print the report when every game is finished
+func (l *LoadTest) Start(ctx context.Context) error {
+	if l.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.cfg.Timeout)
+		defer cancel()
+	}
+
+	conn, err := grpc.DialContext(ctx, l.cfg.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := quiz.NewQuizClient(conn)
+
+	rooms := []string{}
+	for i := 0; i < l.cfg.Rooms; i++ {
+		room, err := client.CreateRoom(ctx, &quiz.CreateRoomRequest{Name: fmt.Sprintf("loadtest-%d", i+1)})
+		if err != nil {
+			return fmt.Errorf("create room: %w", err)
+		}
+		rooms = append(rooms, room.Id)
+	}
+
+	fmt.Fprintf(l.out, "joining %d players to %d rooms, answer latency %s\n", l.cfg.Players, len(rooms), l.cfg.Latency)
+
+	seed := rand.New(rand.NewSource(l.cfg.Seed))
+	players := []*simPlayer{}
+	for i := 0; i < l.cfg.Players; i++ {
+		r := rand.New(rand.NewSource(seed.Int63()))
+		players = append(players, &simPlayer{
+			name:     fmt.Sprintf("load-%d", i+1),
+			room:     rooms[i%len(rooms)],
+			accuracy: l.cfg.Accuracy.Sample(r),
+			rand:     r,
+			received: map[string]bool{},
+		})
+	}
+
+	// every player is joined before the game is started, so every player receive the first question
+	var joined, played sync.WaitGroup
+	started := make(chan struct{})
+	began := time.Now()
+	for _, p := range players {
+		joined.Add(1)
+		played.Add(1)
+		go func(p *simPlayer) {
+			defer played.Done()
+			l.play(ctx, p, joined.Done, started)
+		}(p)
+	}
+	joined.Wait()
+
+	fmt.Fprintf(l.out, "%d players joined in %s, starting the games\n", l.totalJoined(players), time.Since(began).Round(time.Millisecond))
+	for _, room := range rooms {
+		if _, err := client.StartGame(ctx, &quiz.RoomRequest{Room: room}); err != nil {
+			l.fail(err)
+			fmt.Fprintf(l.out, "start room %s: %s\n", room, status.Convert(err).Message())
+		}
+	}
+	close(started)
+	played.Wait()
+
+	l.report(players, time.Since(began))
+
+	return nil
+}
+
+// play join the player and answer every question until the final leaderboard, joined is called once the player is joined or failed
+func (l *LoadTest) play(ctx context.Context, p *simPlayer, joined func(), started <-chan struct{}) {
+	once := sync.Once{}
+	defer once.Do(joined)
+
+	conn, err := grpc.DialContext(ctx, l.cfg.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		l.fail(err)
+		return
+	}
+	defer conn.Close()
+
+	joinedAt := time.Now()
+	stream, err := l.join(ctx, quiz.NewQuizClient(conn), p)
+	if err != nil {
+		l.fail(err)
+		return
+	}
+	l.record(&l.joins, time.Since(joinedAt))
+	p.joined = true
+	once.Do(joined)
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-started:
+	}
+
+	// the answer is sent by the timer, the stream is not safe for the concurrent send
+	var sendMu sync.Mutex
+	for {
+		res, err := stream.Recv()
+		if err != nil {
+			// the player is stopped by the timeout
+			if ctx.Err() == nil {
+				l.fail(err)
+			}
+			return
+		}
+		if res.Timestamp != nil {
+			l.record(&l.events, time.Since(res.Timestamp.AsTime()))
+		}
+
+		switch res.Event.(type) {
+		case *quiz.StreamResponse_Question:
+			question := res.GetQuestion()
+			p.received[fmt.Sprintf("question %d", question.Round)] = true
+
+			correct := p.rand.Float64() < p.accuracy
+			answer := l.cfg.Answers.Answer(question, correct, p.rand)
+			time.AfterFunc(l.cfg.Latency.Sample(p.rand), func() {
+				sendMu.Lock()
+				defer sendMu.Unlock()
+
+				if err := stream.Send(&quiz.Message{Message: answer}); err != nil {
+					return
+				}
+				l.answered(correct)
+			})
+		case *quiz.StreamResponse_RoundResult:
+			p.received[fmt.Sprintf("result %d", res.GetRoundResult().Round)] = true
+		case *quiz.StreamResponse_Leaderboard:
+			if !res.GetLeaderboard().Final {
+				continue
+			}
+
+			p.received["final"] = true
+			p.finished = true
+			sendMu.Lock()
+			defer sendMu.Unlock()
+			_ = stream.CloseSend()
+			return
+		case *quiz.StreamResponse_ServerShutdown:
+			return
+		}
+	}
+}
+
+// join register the player and open the stream, the stream is opened again until the room insert the player
+func (l *LoadTest) join(ctx context.Context, client quiz.QuizClient, p *simPlayer) (quiz.Quiz_StreamClient, error) {
+	if _, err := client.Register(ctx, &quiz.RegisterRequest{Player: p.name, Room: p.room}); err != nil {
+		return nil, err
+	}
+
+	ctx = metadata.NewOutgoingContext(ctx, metadata.New(map[string]string{"player": p.name, "room": p.room}))
+	for {
+		stream, err := client.Stream(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// the header is sent when the stream is accepted, the rejected stream has no header
+		md, err := stream.Header()
+		if err == nil && md != nil {
+			return stream, nil
+		}
+
+		_, err = stream.Recv()
+		if status.Code(err) != codes.Unauthenticated {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(joinRetry):
+		}
+	}
+}
+
+func (l *LoadTest) record(latencies *[]time.Duration, latency time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	*latencies = append(*latencies, latency)
+}
+
+func (l *LoadTest) answered(correct bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.answers++
+	if correct {
+		l.correct++
+	}
+}
+
+// fail count the error of the server, the end of the stream and the cancel of the load test is not counted
+func (l *LoadTest) fail(err error) {
+	if err == nil || errors.Is(err, io.EOF) || errors.Is(err, context.Canceled) || status.Code(err) == codes.Canceled {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	code := status.Code(err)
+	l.errors[code]++
+	if _, ok := l.reasons[code]; !ok {
+		l.reasons[code] = status.Convert(err).Message()
+	}
+}
+
+func (l *LoadTest) totalJoined(players []*simPlayer) int {
+	total := 0
+	for _, p := range players {
+		if p.joined {
+			total++
+		}
+	}
+
+	return total
+}
+
+// dropped is the round event missed by the finished player, compared to every event received in the same room
+func dropped(players []*simPlayer) int {
+	seen := map[string]map[string]bool{}
+	for _, p := range players {
+		if seen[p.room] == nil {
+			seen[p.room] = map[string]bool{}
+		}
+		for event := range p.received {
+			seen[p.room][event] = true
+		}
+	}
+
+	total := 0
+	for _, p := range players {
+		if p.finished {
+			total += len(seen[p.room]) - len(p.received)
+		}
+	}
+
+	return total
+}
+
+func (l *LoadTest) report(players []*simPlayer, elapsed time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	finished := 0
+	for _, p := range players {
+		if p.finished {
+			finished++
+		}
+	}
+
+	fmt.Fprintf(l.out, "=== load test report (%s) ===\n", elapsed.Round(time.Millisecond))
+	fmt.Fprintf(l.out, "players: %d joined, %d finished of %d\n", l.totalJoined(players), finished, len(players))
+	fmt.Fprintf(l.out, "join latency: %s\n", usecase.SummarizeLatency(l.joins))
+	fmt.Fprintf(l.out, "event delivery latency: %s\n", usecase.SummarizeLatency(l.events))
+	fmt.Fprintf(l.out, "answers: %d sent, %d meant to be correct\n", l.answers, l.correct)
+	fmt.Fprintf(l.out, "dropped messages: %d\n", dropped(players))
+
+	failed := []codes.Code{}
+	total := 0
+	for code, count := range l.errors {
+		failed = append(failed, code)
+		total += count
+	}
+	sort.Slice(failed, func(i, j int) bool { return failed[i] < failed[j] })
+
+	fmt.Fprintf(l.out, "server errors: %d\n", total)
+	for _, code := range failed {
+		fmt.Fprintf(l.out, "  %s: %d, e.g. %s\n", code, l.errors[code], l.reasons[code])
+	}
+}
@@ -0,0 +1,253 @@
+// Package practice ....
+package practice
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elangreza14/grpc-quiz/internal/usecase"
+	quiz "github.com/elangreza14/grpc-quiz/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Practice is single player practice with instant feedback.
+// It play in a new room of the server when the address is set,
+// otherwise the game is played in-process without network listener
+type Practice struct {
+	mu       sync.Mutex
+	player   string
+	addr     string
+	round    int32
+	keys     []string
+	answers  map[int32]string
+	Terminal *usecase.Terminal
+	// Questions is only used in-process, nil mean the default question
+	Questions []usecase.QuestionPayload
+}
+
+// NewPractice is ...
+func NewPractice(player, addr string) *Practice {
+	return &Practice{
+		player:   player,
+		addr:     addr,
+		answers:  map[int32]string{},
+		Terminal: usecase.NewTerminal(os.Stdin),
+	}
+}
+
+// Start is ...
+func (p *Practice) Start(ctx context.Context) error {
+	if p.addr == "" {
+		return p.startLocal(ctx)
+	}
+
+	return p.startRemote(ctx)
+}
+
+// startLocal play the game engine directly, there is no room and no server
+func (p *Practice) startLocal(ctx context.Context) error {
+	game, err := usecase.NewGamePlay(usecase.GameConfig{Mode: usecase.PracticeMode, Questions: p.Questions})
+	if err != nil {
+		return err
+	}
+
+	game.AddPlayer(p.player)
+	game.Start()
+
+	go p.listenTerminal(ctx, func(answer string) error {
+		game.SubmitAnswer(usecase.SubmitAnswerPayload{
+			Name:   p.player,
+			Answer: answer,
+		})
+		return nil
+	})
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case state := <-game.ListenStream():
+			if state.State == usecase.Done {
+				p.printScore(game.Scores())
+				return nil
+			}
+
+			if res, ok := state.Event(); ok {
+				p.printEvent(res)
+			}
+		}
+	}
+}
+
+// startRemote create a practice room in the server and play it
+func (p *Practice) startRemote(ctx context.Context) error {
+	conn, err := grpc.DialContext(ctx, p.addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := quiz.NewQuizClient(conn)
+
+	room, err := client.CreateRoom(ctx, &quiz.CreateRoomRequest{
+		Name: fmt.Sprintf("practice %s", p.player),
+		Mode: usecase.PracticeMode,
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err = client.Register(ctx, &quiz.RegisterRequest{Player: p.player, Room: room.Id}); err != nil {
+		return err
+	}
+
+	md := metadata.New(map[string]string{"player": p.player, "room": room.Id})
+	streamer, err := client.Stream(metadata.NewOutgoingContext(ctx, md))
+	if err != nil {
+		return err
+	}
+
+	go p.startGame(ctx, client, room.Id)
+	go p.listenTerminal(ctx, func(answer string) error {
+		return streamer.Send(&quiz.Message{Message: answer})
+	})
+
+	for {
+		res, err := streamer.Recv()
+		if status.Code(err) == codes.Canceled {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if done := p.printEvent(res); done {
+			return nil
+		}
+	}
+}
+
+// startGame wait until the player is in the room, there is no host in the practice
+func (p *Practice) startGame(ctx context.Context, client quiz.QuizClient, room string) {
+	for i := 0; i < 50; i++ {
+		_, err := client.StartGame(ctx, &quiz.RoomRequest{Room: room})
+		if status.Code(err) != codes.FailedPrecondition {
+			return
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func (p *Practice) listenTerminal(ctx context.Context, submit func(answer string) error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			val, ok := p.Terminal.ValText()
+			if !ok {
+				return
+			}
+
+			answer := strings.ToUpper(strings.TrimSpace(val))
+			p.mu.Lock()
+			keys := p.keys
+			valid := false
+			for _, key := range keys {
+				valid = valid || key == answer
+			}
+			if valid {
+				if _, ok := p.answers[p.round]; !ok {
+					p.answers[p.round] = answer
+				}
+			}
+			p.mu.Unlock()
+
+			if !valid {
+				fmt.Printf("only accept (%s)\n", strings.Join(keys, "/"))
+				continue
+			}
+
+			if err := submit(answer); err != nil {
+				fmt.Println(err.Error())
+				return
+			}
+		}
+	}
+}
+
+// printEvent print the feedback of the answer, return true when the practice is finished
+func (p *Practice) printEvent(res *quiz.StreamResponse) bool {
+	switch res.Event.(type) {
+	case *quiz.StreamResponse_ServerAnnouncement:
+		fmt.Println(res.GetServerAnnouncement().Message)
+	case *quiz.StreamResponse_Question:
+		question := res.GetQuestion()
+		p.mu.Lock()
+		p.round = question.Round
+		p.keys = []string{"Y", "N"}
+		if len(question.Options) > 0 {
+			p.keys = []string{}
+			for _, option := range question.Options {
+				p.keys = append(p.keys, option.Key)
+			}
+		}
+		p.mu.Unlock()
+
+		if len(question.Options) == 0 {
+			fmt.Printf("\nquestion %d: %s (Y/N)\n", question.Round, question.Question)
+			break
+		}
+
+		fmt.Printf("\nquestion %d: %s\n", question.Round, question.Question)
+		for _, option := range question.Options {
+			fmt.Printf("  %s. %s\n", option.Key, option.Text)
+		}
+	case *quiz.StreamResponse_RoundResult:
+		result := res.GetRoundResult()
+		p.mu.Lock()
+		answer, ok := p.answers[result.Round]
+		p.mu.Unlock()
+
+		switch {
+		case !ok:
+			fmt.Printf("time is up, the answer is %s\n", result.Answer)
+		case answer == result.Answer:
+			fmt.Println("correct!")
+		default:
+			fmt.Printf("wrong, the answer is %s\n", result.Answer)
+		}
+
+		if result.Explanation != "" {
+			fmt.Printf("explanation: %s\n", result.Explanation)
+		}
+	case *quiz.StreamResponse_Leaderboard:
+		if leaderboard := res.GetLeaderboard(); leaderboard.Final {
+			for _, score := range leaderboard.Scores {
+				if score.Player == p.player {
+					fmt.Printf("\npractice finished, you got %d points\n", score.Point)
+				}
+			}
+		}
+	case *quiz.StreamResponse_ServerShutdown:
+		return true
+	}
+
+	return false
+}
+
+func (p *Practice) printScore(scores []usecase.PlayerScore) {
+	for _, score := range scores {
+		if score.Name == p.player {
+			fmt.Printf("\npractice finished, you got %d points\n", score.Point)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// Package present ....
+package present
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/elangreza14/grpc-quiz/internal/usecase"
+	quiz "github.com/elangreza14/grpc-quiz/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// frameRate is how often the presenter view is rendered
+const frameRate = 100 * time.Millisecond
+
+// Presenter render the game in big-type for the projector.
+// It connect as host when the host key is set, otherwise as spectator
+type Presenter struct {
+	room     string
+	hostKey  string
+	client   quiz.QuizClient
+	admin    quiz.AdminClient
+	out      io.Writer
+	view     *view
+	Terminal *usecase.Terminal
+}
+
+// NewPresenter is ...
+func NewPresenter(room, hostKey string) *Presenter {
+	return &Presenter{
+		room:     room,
+		hostKey:  hostKey,
+		out:      os.Stdout,
+		view:     newView(room, hostKey != ""),
+		Terminal: usecase.NewTerminal(os.Stdin),
+	}
+}
+
+// Start is ...
+func (p *Presenter) Start(ctx context.Context) error {
+	conn, err := grpc.DialContext(ctx, ":50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	p.client = quiz.NewQuizClient(conn)
+	p.admin = quiz.NewAdminClient(conn)
+
+	streamer, err := p.client.Spectate(ctx, &quiz.SpectateRequest{
+		Room:    p.room,
+		Name:    "presenter",
+		HostKey: p.hostKey,
+	})
+	if err != nil {
+		return err
+	}
+
+	events := make(chan *quiz.StreamResponse)
+	errs := make(chan error, 1)
+	go func() {
+		for {
+			res, err := streamer.Recv()
+			if err != nil {
+				errs <- err
+				return
+			}
+			events <- res
+		}
+	}()
+
+	// only the host can start the game
+	if p.hostKey != "" {
+		go p.listenTerminal(ctx)
+	}
+
+	ticker := time.NewTicker(frameRate)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errs:
+			return err
+		case evt := <-events:
+			p.view.apply(evt, time.Now())
+			p.view.render(p.out, time.Now())
+			if evt.GetServerShutdown() != nil {
+				return nil
+			}
+		case <-ticker.C:
+			p.view.render(p.out, time.Now())
+		}
+	}
+}
+
+func (p *Presenter) listenTerminal(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			text, ok := p.Terminal.ValText()
+			if !ok {
+				return
+			}
+
+			if strings.HasPrefix(text, "/") {
+				p.control(ctx, text)
+				continue
+			}
+
+			res, err := p.client.StartGame(ctx, &quiz.RoomRequest{Room: p.room})
+			if err != nil {
+				p.view.setStatus(status.Convert(err).Message())
+				continue
+			}
+			p.view.setStatus(res.Message)
+		}
+	}
+}
+
+// control send the command of the host to the round, e.g. /pause or /extend 30
+func (p *Presenter) control(ctx context.Context, command string) {
+	action, extend, err := usecase.ParseRoundControl(command)
+	if err != nil {
+		p.view.setStatus(err.Error())
+		return
+	}
+
+	req := &quiz.ControlRoundRequest{
+		HostKey: p.hostKey,
+		Room:    p.room,
+		Action:  quiz.ControlRoundRequest_Action(action),
+	}
+	if extend > 0 {
+		req.Extend = durationpb.New(extend)
+	}
+
+	if _, err := p.admin.ControlRound(ctx, req); err != nil {
+		p.view.setStatus(status.Convert(err).Message())
+	}
+}
@@ -0,0 +1,391 @@
+package present
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	quiz "github.com/elangreza14/grpc-quiz/proto"
+)
+
+const (
+	width         = 78
+	barWidth      = 40
+	topPlayer     = 10
+	animationTime = time.Second
+
+	clearScreen = "\033[H\033[2J"
+	bold        = "\033[1m"
+	green       = "\033[32m"
+	reset       = "\033[0m"
+)
+
+var bigDigits = map[rune][5]string{
+	'0': {"█████", "█   █", "█   █", "█   █", "█████"},
+	'1': {"  █  ", " ██  ", "  █  ", "  █  ", " ███ "},
+	'2': {"█████", "    █", "█████", "█    ", "█████"},
+	'3': {"█████", "    █", " ████", "    █", "█████"},
+	'4': {"█   █", "█   █", "█████", "    █", "    █"},
+	'5': {"█████", "█    ", "█████", "    █", "█████"},
+	'6': {"█████", "█    ", "█████", "█   █", "█████"},
+	'7': {"█████", "    █", "   █ ", "  █  ", "  █  "},
+	'8': {"█████", "█   █", "█████", "█   █", "█████"},
+	'9': {"█████", "█   █", "█████", "    █", "█████"},
+}
+
+type (
+	// view is the state of the presenter screen
+	view struct {
+		mu       sync.Mutex
+		room     string
+		host     bool
+		status   string
+		question *quiz.Question
+		// paused freeze the countdown of the question until the round is resumed
+		paused      *quiz.Paused
+		progress    *quiz.AnswerProgress
+		buzzer      *quiz.Buzzer
+		result      *quiz.RoundResult
+		leaderboard []rank
+		teams       []*quiz.TeamScore
+		final       bool
+		changedAt   time.Time
+	}
+
+	// rank is the row of the leaderboard, it move from the previous row to the current row
+	rank struct {
+		player            string
+		fromRow, toRow    int
+		fromPoint, points int32
+		eliminated        bool
+		bot               bool
+	}
+)
+
+func newView(room string, host bool) *view {
+	if room == "" {
+		room = "default"
+	}
+
+	status := "waiting the host to start the game"
+	if host {
+		status = "press (Enter) to start the game, /pause, /resume, /skip, /extend [seconds] and /next to control the round"
+	}
+
+	return &view{
+		room:   room,
+		host:   host,
+		status: status,
+	}
+}
+
+func (v *view) setStatus(status string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.status = status
+}
+
+func (v *view) apply(res *quiz.StreamResponse, now time.Time) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	switch res.Event.(type) {
+	case *quiz.StreamResponse_ServerAnnouncement:
+		v.status = res.GetServerAnnouncement().Message
+	case *quiz.StreamResponse_Question:
+		v.question = res.GetQuestion()
+		v.paused = nil
+		v.progress = nil
+		v.buzzer = nil
+	case *quiz.StreamResponse_Paused:
+		v.paused = res.GetPaused()
+		v.status = fmt.Sprintf("round %d is paused", v.paused.Round)
+	case *quiz.StreamResponse_Resumed:
+		resumed := res.GetResumed()
+		if v.question != nil && v.question.Round == resumed.Round {
+			v.question.Deadline = resumed.Deadline
+		}
+		v.paused = nil
+		v.status = fmt.Sprintf("round %d is resumed", resumed.Round)
+	case *quiz.StreamResponse_Review:
+		review := res.GetReview()
+		v.status = fmt.Sprintf("review of round %d / %d, waiting the host to start the next round", review.Round, review.TotalRound)
+		if v.host {
+			v.status = fmt.Sprintf("review of round %d / %d, type /next to start the next round", review.Round, review.TotalRound)
+		}
+	case *quiz.StreamResponse_AnswerProgress:
+		v.progress = res.GetAnswerProgress()
+	case *quiz.StreamResponse_Buzzer:
+		v.buzzer = res.GetBuzzer()
+	case *quiz.StreamResponse_RoundResult:
+		v.result = res.GetRoundResult()
+	case *quiz.StreamResponse_Leaderboard:
+		v.updateLeaderboard(res.GetLeaderboard(), now)
+	case *quiz.StreamResponse_ServerShutdown:
+		v.status = "server shuting down"
+	}
+}
+
+// updateLeaderboard start the animation from the current row to the new row
+func (v *view) updateLeaderboard(leaderboard *quiz.Leaderboard, now time.Time) {
+	previous := map[string]rank{}
+	for _, r := range v.leaderboard {
+		previous[r.player] = r
+	}
+
+	ranks := []rank{}
+	for i, score := range leaderboard.Scores {
+		if i == topPlayer {
+			break
+		}
+
+		// new player in the top will come from the bottom
+		r := rank{player: score.Player, fromRow: topPlayer, toRow: i, points: score.Point, eliminated: score.Eliminated, bot: score.Bot}
+		if prev, ok := previous[score.Player]; ok {
+			r.fromRow = prev.toRow
+			r.fromPoint = prev.points
+		}
+		ranks = append(ranks, r)
+	}
+
+	v.leaderboard = ranks
+	v.teams = leaderboard.Teams
+	v.final = leaderboard.Final
+	v.changedAt = now
+}
+
+func (v *view) render(w io.Writer, now time.Time) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	b := &strings.Builder{}
+	b.WriteString(clearScreen)
+	fmt.Fprintf(b, "%s%s%s\n", bold, center(fmt.Sprintf("GRPC QUIZ - room %s", v.room), width), reset)
+	b.WriteString(strings.Repeat("═", width) + "\n\n")
+
+	if v.question != nil {
+		v.renderQuestion(b, now)
+	}
+
+	if v.result != nil {
+		v.renderResult(b)
+	}
+
+	if len(v.teams) > 0 {
+		v.renderTeams(b)
+	}
+
+	if len(v.leaderboard) > 0 {
+		v.renderLeaderboard(b, now)
+	}
+
+	b.WriteString(strings.Repeat("─", width) + "\n")
+	b.WriteString(v.status + "\n")
+
+	_, _ = io.WriteString(w, b.String())
+}
+
+func (v *view) renderQuestion(b *strings.Builder, now time.Time) {
+	if v.question.SuddenDeath {
+		fmt.Fprintf(b, "ROUND %d - %sSUDDEN DEATH%s\n\n", v.question.Round, bold, reset)
+	} else {
+		fmt.Fprintf(b, "ROUND %d / %d  %s\n\n", v.question.Round, v.question.TotalRound, strings.ToUpper(v.question.Difficulty))
+	}
+	for _, line := range wrap(v.question.Question, width) {
+		fmt.Fprintf(b, "%s%s%s\n", bold, center(line, width), reset)
+	}
+	b.WriteString("\n")
+
+	for _, option := range v.question.Options {
+		fmt.Fprintf(b, "  %s%s%s  %s\n", bold, option.Key, reset, option.Text)
+	}
+	if len(v.question.Options) > 0 {
+		b.WriteString("\n")
+	}
+
+	remaining := 0
+	if v.paused != nil && v.paused.Round == v.question.Round {
+		remaining = int(math.Ceil(v.paused.Remaining.AsDuration().Seconds()))
+		fmt.Fprintf(b, "%s%s%s\n", bold, center("PAUSED", width), reset)
+	} else if v.question.Deadline != nil {
+		remaining = int(math.Ceil(v.question.Deadline.AsTime().Sub(now).Seconds()))
+	}
+	if remaining < 0 || (v.result != nil && v.result.Round == v.question.Round) {
+		remaining = 0
+	}
+
+	for _, line := range bigNumber(remaining) {
+		b.WriteString(center(line, width) + "\n")
+	}
+	b.WriteString("\n")
+
+	var answered, players int32
+	if v.progress != nil && v.progress.Round == v.question.Round {
+		answered, players = v.progress.TotalAnswer, v.progress.TotalPlayer
+	}
+	fmt.Fprintf(b, "answers %s %d / %d\n\n", bar(answered, players, barWidth), answered, players)
+
+	if v.buzzer != nil && v.buzzer.Round == v.question.Round {
+		v.renderBuzzer(b)
+	}
+}
+
+func (v *view) renderBuzzer(b *strings.Builder) {
+	if v.buzzer.Holder != "" {
+		fmt.Fprintf(b, "%sBUZZER%s  %s%s%s\n", bold, reset, green, v.buzzer.Holder, reset)
+	} else {
+		fmt.Fprintf(b, "%sBUZZER%s  open\n", bold, reset)
+	}
+
+	if len(v.buzzer.LockedOut) > 0 {
+		fmt.Fprintf(b, "locked out: %s\n", strings.Join(v.buzzer.LockedOut, ", "))
+	}
+	b.WriteString("\n")
+}
+
+func (v *view) renderResult(b *strings.Builder) {
+	fmt.Fprintf(b, "%sROUND %d RESULT%s  answer: %s\n", bold, v.result.Round, reset, v.result.Answer)
+
+	for _, option := range v.result.Distribution {
+		line := fmt.Sprintf("  %-3s %s %d", option.Option, bar(option.Total, v.result.TotalAnswer, barWidth), option.Total)
+		if option.Option == v.result.Answer {
+			line = green + line + " ✔" + reset
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n")
+}
+
+func (v *view) renderLeaderboard(b *strings.Builder, now time.Time) {
+	title := "LEADERBOARD"
+	if v.final {
+		title = "FINAL LEADERBOARD"
+	}
+	fmt.Fprintf(b, "%s%s%s\n", bold, title, reset)
+
+	progress := float64(now.Sub(v.changedAt)) / float64(animationTime)
+	if progress > 1 {
+		progress = 1
+	}
+	progress = 1 - math.Pow(1-progress, 3)
+
+	type row struct {
+		position   float64
+		player     string
+		points     int32
+		eliminated bool
+		bot        bool
+	}
+
+	rows := []row{}
+	var top int32 = 1
+	for _, r := range v.leaderboard {
+		rows = append(rows, row{
+			position:   float64(r.fromRow) + float64(r.toRow-r.fromRow)*progress,
+			player:     r.player,
+			points:     r.fromPoint + int32(math.Round(float64(r.points-r.fromPoint)*progress)),
+			eliminated: r.eliminated,
+			bot:        r.bot,
+		})
+		if r.points > top {
+			top = r.points
+		}
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].position < rows[j].position
+	})
+
+	for i, r := range rows {
+		tag := ""
+		if r.bot {
+			tag = " 🤖"
+		}
+		if r.eliminated {
+			tag += " ✖"
+		}
+		fmt.Fprintf(b, "%3d. %-16s %s %d%s\n", i+1, truncate(r.player, 16), bar(r.points, top, barWidth), r.points, tag)
+	}
+	b.WriteString("\n")
+}
+
+func (v *view) renderTeams(b *strings.Builder) {
+	fmt.Fprintf(b, "%sTEAMS%s\n", bold, reset)
+
+	top := 1.0
+	for _, team := range v.teams {
+		top = math.Max(top, team.Point)
+	}
+
+	for i, team := range v.teams {
+		fmt.Fprintf(b, "%3d. %-16s %s %.2f\n", i+1, truncate(team.Team, 16), bar(int32(team.Point*100), int32(top*100), barWidth), team.Point)
+	}
+	b.WriteString("\n")
+}
+
+func bigNumber(number int) []string {
+	lines := make([]string, 5)
+	for _, digit := range fmt.Sprint(number) {
+		for i, part := range bigDigits[digit] {
+			lines[i] += part + " "
+		}
+	}
+
+	return lines
+}
+
+// bar is clamped, the value may be more than the total or negative
+func bar(value, total int32, size int) string {
+	filled := 0
+	if total > 0 {
+		filled = int(value) * size / int(total)
+	}
+	if filled < 0 {
+		filled = 0
+	} else if filled > size {
+		filled = size
+	}
+
+	return strings.Repeat("█", filled) + strings.Repeat("░", size-filled)
+}
+
+func center(text string, size int) string {
+	length := len([]rune(text))
+	if length >= size {
+		return text
+	}
+
+	return strings.Repeat(" ", (size-length)/2) + text
+}
+
+func truncate(text string, size int) string {
+	runes := []rune(text)
+	if len(runes) <= size {
+		return text
+	}
+
+	return string(runes[:size-1]) + "…"
+}
+
+func wrap(text string, size int) []string {
+	lines := []string{}
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len([]rune(line))+1+len([]rune(word)) > size {
+			lines = append(lines, line)
+			line = ""
+		}
+
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+
+	return append(lines, line)
+}
@@ -0,0 +1,77 @@
+// Package questions is the tool for the question bank
+package questions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/elangreza14/grpc-quiz/internal/importer"
+	"github.com/elangreza14/grpc-quiz/internal/usecase"
+)
+
+// Import convert the files to one question bank, the skipped question is reported to stderr
+type Import struct {
+	Files []string
+	// Format is empty mean the format is detected from the extension of each file
+	Format string
+	// Output is empty mean the bank is printed to stdout
+	Output string
+	Name   string
+	// Category is set to the question without category
+	Category string
+	// Strict return error when any question is skipped
+	Strict bool
+}
+
+// Start is ...
+func (i *Import) Start(context.Context) error {
+	if len(i.Files) == 0 {
+		return errors.New("file to import is required")
+	}
+
+	questions := []usecase.Question{}
+	skipped := 0
+	for _, file := range i.Files {
+		imported, issues, err := importer.ImportFile(file, i.Format)
+		if err != nil {
+			return err
+		}
+
+		for _, issue := range issues {
+			fmt.Fprintf(os.Stderr, "%s %s\n", file, issue)
+		}
+		fmt.Fprintf(os.Stderr, "%s: %d question imported, %d issue\n", file, len(imported), len(issues))
+
+		for _, question := range imported {
+			if question.Category == "" {
+				question.Category = i.Category
+			}
+			questions = append(questions, question)
+		}
+		skipped += len(issues)
+	}
+
+	if i.Strict && skipped > 0 {
+		return fmt.Errorf("%d issue found", skipped)
+	}
+
+	name := i.Name
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(i.Files[0]), filepath.Ext(i.Files[0]))
+	}
+	bank := importer.NewBank(name, questions)
+
+	if i.Output != "" {
+		return bank.Save(i.Output)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(bank)
+}
@@ -0,0 +1,140 @@
+package questions
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/elangreza14/grpc-quiz/internal/usecase"
+)
+
+// newQuestion ask every field of the question and append it to the bank file,
+// the bank file is created when it doesn't exist
+func (c *Command) newQuestion() error {
+	bank := &usecase.QuestionBank{}
+	if _, err := os.Stat(c.Path); err == nil {
+		if bank, err = usecase.LoadBank(c.Path); err != nil {
+			return err
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	} else {
+		bank.Name = c.prompt("bank name")
+	}
+
+	if len(bank.Categories) > 0 {
+		fmt.Fprintf(c.out, "categories: %s\n", strings.Join(bank.Categories, ", "))
+	}
+
+	for {
+		question := usecase.Question{
+			ID:       c.prompt("id (optional)"),
+			Question: c.prompt("question"),
+		}
+
+		fmt.Fprintln(c.out, "options, one per line. empty line to finish, no option for the true or false question")
+		for i := 0; ; i++ {
+			option := c.prompt(fmt.Sprintf("option %c", 'A'+i))
+			if option == "" {
+				break
+			}
+			question.Options = append(question.Options, option)
+		}
+
+		if len(question.Options) == 0 {
+			question.Answer = c.prompt("answer (Y/N)")
+		} else {
+			question.Answer = c.prompt(fmt.Sprintf("answer (A-%c)", 'A'+len(question.Options)-1))
+		}
+		question.Answer = strings.ToUpper(question.Answer)
+
+		question.Explanation = c.prompt("explanation")
+		question.Difficulty = c.prompt("difficulty (easy/medium/hard)")
+		question.Category = c.prompt("category")
+		question.Hints = list(c.prompt("hints, separated by |"))
+		question.Tags = list(c.prompt("tags, separated by ,"), ",")
+		question.TimeLimit = number(c.prompt("time limit in seconds (optional)"))
+		question.Weight = number(c.prompt("weight of the point (optional)"))
+
+		candidate := *bank
+		candidate.Questions = append(append([]usecase.Question{}, bank.Questions...), question)
+		if question.Category != "" && len(bank.Categories) > 0 && !contains(bank.Categories, question.Category) {
+			candidate.Categories = append(append([]string{}, bank.Categories...), question.Category)
+		}
+
+		// only the issue of the new question is shown
+		issues := []usecase.LintIssue{}
+		for _, issue := range candidate.Lint() {
+			if issue.Question == len(candidate.Questions) {
+				issues = append(issues, issue)
+			}
+		}
+
+		failed := false
+		for _, issue := range issues {
+			fmt.Fprintln(c.out, issue)
+			failed = failed || issue.Severity == usecase.LintError
+		}
+
+		if failed {
+			if c.confirm("the question is invalid, try again?") {
+				continue
+			}
+
+			return errors.New("the question is not saved")
+		}
+
+		*bank = candidate
+		if err := bank.Save(c.Path); err != nil {
+			return err
+		}
+		fmt.Fprintf(c.out, "question %d is saved to %s\n", len(bank.Questions), c.Path)
+
+		if !c.confirm("add another question?") {
+			return nil
+		}
+	}
+}
+
+func (c *Command) prompt(label string) string {
+	fmt.Fprintf(c.out, "%s: ", label)
+	if !c.in.Scan() {
+		return ""
+	}
+
+	return strings.TrimSpace(c.in.Text())
+}
+
+func (c *Command) confirm(label string) bool {
+	return strings.EqualFold(c.prompt(label+" (Y/N)"), "Y")
+}
+
+// number is zero when the value is empty or not a number, the negative number is reported by the lint
+func number(s string) int {
+	n, _ := strconv.Atoi(s)
+
+	return n
+}
+
+// list split the value, the default separator is |
+func list(s string, separator ...string) []string {
+	sep := "|"
+	if len(separator) > 0 {
+		sep = separator[0]
+	}
+
+	values := []string{}
+	for _, value := range strings.Split(s, sep) {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	return values
+}
@@ -0,0 +1,190 @@
+package questions
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	practice "github.com/elangreza14/grpc-quiz/cmd/practice"
+	"github.com/elangreza14/grpc-quiz/internal/usecase"
+)
+
+const (
+	// Lint is the action for validating the bank
+	Lint = "lint"
+	// Stats is the action for counting the question by category and difficulty
+	Stats = "stats"
+	// New is the action for writing a new question interactively
+	New = "new"
+	// Preview is the action for playing the bank locally
+	Preview = "preview"
+)
+
+// Command is the questions command of the cli
+type Command struct {
+	Action string
+	// Path is the bank file or the directory of the bank, new question is appended to the file
+	Path string
+	// Strict fail the lint on warning
+	Strict bool
+	// Draw, Shuffle and Seed pick the question of the preview
+	Draw    []usecase.DrawRule
+	Shuffle bool
+	Seed    int64
+	Player  string
+
+	in  *bufio.Scanner
+	out io.Writer
+}
+
+// NewCommand is ...
+func NewCommand(action string) *Command {
+	return &Command{
+		Action: action,
+		in:     bufio.NewScanner(os.Stdin),
+		out:    os.Stdout,
+	}
+}
+
+// Start is ...
+func (c *Command) Start(ctx context.Context) error {
+	if c.Path == "" {
+		return errors.New("question bank is required")
+	}
+
+	switch c.Action {
+	case Lint:
+		return c.lint()
+	case Stats:
+		return c.stats()
+	case New:
+		return c.newQuestion()
+	case Preview:
+		return c.preview(ctx)
+	default:
+		return fmt.Errorf("questions action %s not found, use lint, stats, new or preview", c.Action)
+	}
+}
+
+func (c *Command) lint() error {
+	bank, err := usecase.LoadBank(c.Path)
+	if err != nil {
+		return err
+	}
+
+	issues := bank.Lint()
+	errs, warnings := 0, 0
+	for _, issue := range issues {
+		if issue.Severity == usecase.LintError {
+			errs++
+		} else {
+			warnings++
+		}
+
+		if issue.Question > 0 {
+			fmt.Fprintf(c.out, "%s %q\n", issue, bank.Questions[issue.Question-1].Question)
+		} else {
+			fmt.Fprintln(c.out, issue)
+		}
+	}
+	fmt.Fprintf(c.out, "%d question, %d error, %d warning\n", len(bank.Questions), errs, warnings)
+
+	if errs > 0 || c.Strict && warnings > 0 {
+		return errors.New("lint failed")
+	}
+
+	return nil
+}
+
+func (c *Command) stats() error {
+	bank, err := usecase.LoadBank(c.Path)
+	if err != nil {
+		return err
+	}
+
+	stats := bank.Stats()
+
+	// the invalid difficulty is shown after the known difficulty
+	difficulties := []string{usecase.DifficultyName(usecase.Easy), usecase.DifficultyName(usecase.Medium), usecase.DifficultyName(usecase.Hard)}
+	for _, category := range stats.Categories {
+		for difficulty := range category.Difficulty {
+			if !contains(difficulties, difficulty) {
+				difficulties = append(difficulties, difficulty)
+			}
+		}
+	}
+	sort.Strings(difficulties[3:])
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(w, "category\t%s\ttotal\t\n", strings.Join(difficulties, "\t"))
+
+	totals := map[string]int{}
+	for _, category := range stats.Categories {
+		name := category.Category
+		if name == "" {
+			name = "(none)"
+		}
+
+		fmt.Fprintf(w, "%s\t", name)
+		for _, difficulty := range difficulties {
+			fmt.Fprintf(w, "%d\t", category.Difficulty[difficulty])
+			totals[difficulty] += category.Difficulty[difficulty]
+		}
+		fmt.Fprintf(w, "%d\t\n", category.Total)
+	}
+
+	fmt.Fprint(w, "total\t")
+	for _, difficulty := range difficulties {
+		fmt.Fprintf(w, "%d\t", totals[difficulty])
+	}
+	fmt.Fprintf(w, "%d\t\n", stats.Total)
+
+	return w.Flush()
+}
+
+// preview play the bank in the terminal with the instant feedback of the practice
+func (c *Command) preview(ctx context.Context) error {
+	bank, err := usecase.LoadBank(c.Path)
+	if err != nil {
+		return err
+	}
+
+	questions, err := bank.Payloads()
+	if err != nil {
+		return err
+	}
+
+	if c.Shuffle || len(c.Draw) > 0 {
+		if c.Seed == 0 {
+			c.Seed = time.Now().UnixNano()
+		}
+
+		if questions, err = usecase.Draw(questions, usecase.DrawConfig{Rules: c.Draw, Seed: c.Seed}, nil); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(c.out, "previewing %d question of %s\n", len(questions), bank.Name)
+
+	game := practice.NewPractice(c.Player, "")
+	game.Questions = questions
+
+	return game.Start(ctx)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"errors"
+	"flag"
+
+	client "github.com/elangreza14/grpc-quiz/cmd/client"
+	"github.com/elangreza14/grpc-quiz/internal/usecase"
+)
+
+func duelCommand(args []string) (runner, error) {
+	fs := flag.NewFlagSet("duel", flag.ExitOnError)
+	player := fs.String("p", "", "player name is required.")
+	timeout := fs.Duration("timeout", usecase.DefaultMatchTimeout, "how long to wait for an opponent.")
+	bot := fs.Bool("bot", false, "play against the bot when no opponent found before timeout.")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if *player == "" {
+		return nil, errors.New("player name is required")
+	}
+
+	return client.NewDuel(*player, *timeout, *bot), nil
+}
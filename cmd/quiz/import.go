@@ -0,0 +1,27 @@
+package main
+
+import (
+	"flag"
+	"strings"
+
+	questions "github.com/elangreza14/grpc-quiz/cmd/questions"
+	"github.com/elangreza14/grpc-quiz/internal/importer"
+)
+
+// importCommand is quiz import -o questions/trivia.json dump.json
+func importCommand(args []string) (runner, error) {
+	cmd := &questions.Import{}
+
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	fs.StringVar(&cmd.Format, "format", "", "format of the files, "+strings.Join(importer.Formats(), ", ")+". empty format is detected from the extension.")
+	fs.StringVar(&cmd.Output, "o", "", "output of the question bank is optional, the bank is printed if empty.")
+	fs.StringVar(&cmd.Name, "name", "", "name of the question bank, default is the name of the first file.")
+	fs.StringVar(&cmd.Category, "category", "", "category of the question without category.")
+	fs.BoolVar(&cmd.Strict, "strict", false, "fail when any question is skipped.")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	cmd.Files = fs.Args()
+
+	return cmd, nil
+}
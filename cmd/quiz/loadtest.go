@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"time"
+
+	loadtest "github.com/elangreza14/grpc-quiz/cmd/loadtest"
+	"github.com/elangreza14/grpc-quiz/internal/usecase"
+)
+
+func loadtestCommand(args []string) (runner, error) {
+	fs := flag.NewFlagSet("loadtest", flag.ExitOnError)
+	addr := fs.String("addr", ":50051", "address of the server.")
+	players := fs.Int("players", 100, "total simulated players, every player open its own connection and stream.")
+	rooms := fs.Int("rooms", 1, "total rooms created for the load test, the players are spread evenly.")
+	latency := fs.String("answer-latency", usecase.DefaultAnswerLatency, "delay of the answer after the question, fixed:2s, uniform:1s-5s, normal:3s,1s or exp:2s.")
+	accuracy := fs.String("accuracy", "0.7", "chance of the correct answer, e.g. 0.7 or 0.5-0.9 for a different accuracy of each player.")
+	bank := fs.String("questions", "", "question bank of the server is optional, it is used to answer correctly. the default questions are used if empty.")
+	timeout := fs.Duration("timeout", 10*time.Minute, "stop the players which is still playing after this duration.")
+	seed := fs.Int64("seed", 0, "seed of the latency and the answer, zero is random.")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if *players <= 0 {
+		return nil, errors.New("players must be positive")
+	}
+
+	dist, err := usecase.ParseLatencyDist(*latency)
+	if err != nil {
+		return nil, err
+	}
+
+	accuracies, err := usecase.ParseAccuracyRange(*accuracy)
+	if err != nil {
+		return nil, err
+	}
+
+	var questions []usecase.QuestionPayload
+	if *bank != "" {
+		b, err := usecase.LoadBank(*bank)
+		if err != nil {
+			return nil, err
+		}
+
+		// the invalid question is not played by the server either
+		questions, _ = b.Payloads()
+	}
+
+	return loadtest.NewLoadTest(loadtest.Config{
+		Addr:     *addr,
+		Players:  *players,
+		Rooms:    *rooms,
+		Latency:  dist,
+		Accuracy: accuracies,
+		Answers:  usecase.NewAnswerKey(questions),
+		Timeout:  *timeout,
+		Seed:     *seed,
+	}), nil
+}
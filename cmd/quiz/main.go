@@ -7,21 +7,67 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	client "github.com/elangreza14/grpc-quiz/cmd/client"
+	gateway "github.com/elangreza14/grpc-quiz/cmd/gateway"
 	server "github.com/elangreza14/grpc-quiz/cmd/server"
+	"github.com/elangreza14/grpc-quiz/internal/usecase"
 )
 
-var player = flag.String("p", "", "player name is optional, if exist will create client runner.")
+var (
+	player     = flag.String("p", "", "player name is optional, if exist will create client runner.")
+	room       = flag.String("r", "", "room is optional, client will join the default room if empty.")
+	httpAddr   = flag.String("http", "", "address of REST gateway is optional, if exist server will serve REST/JSON. e.g. :8080")
+	spectate   = flag.Bool("spectate", false, "spectate the room instead of playing, -p is used as spectator name.")
+	delay      = flag.Duration("delay", 0, "delay of the event for the spectator. on server it is the minimum delay for every spectator.")
+	hostKey    = flag.String("host-key", "", "key for the host to spectate without delay, e.g. for quiz present. The admin service is disabled without the key.")
+	team       = flag.String("t", "", "team is optional. on client it is the team to join, on server it is comma separated teams of the default room. e.g. red,blue")
+	policy     = flag.String("team-policy", "average", "answer policy of the team, average or captain.")
+	mode       = flag.String("mode", usecase.ClassicMode, "game mode of the default room, classic, elimination, buzzer or async.")
+	timeLimit  = flag.Duration("time-limit", 0, "overall time for each player in async mode, zero mean until the quiz is closed.")
+	closesIn   = flag.Duration("closes-in", usecase.DefaultAsyncWindow, "the async quiz is closed and the results are released after this duration.")
+	lifelines  = flag.String("lifelines", "", "usage count of each lifeline for every player in the default room, e.g. fifty=1,skip=1,double=1,time=1. the lifeline which is not set use one.")
+	hintMode   = flag.String("hints", usecase.HintOff, "hint of the default room, off, request or auto. with hints the correct answer is 10 points instead of 1.")
+	hintCost   = flag.Int("hint-cost", usecase.DefaultHintCost, "point reduced for each hint from the 10 points of the correct answer.")
+	hintReveal = flag.String("hint-reveal", "", "fraction of the round time when the hint is unlocked or revealed, e.g. 0.5,0.75. empty spread the hints evenly.")
+	adaptive   = flag.Bool("adaptive", false, "pick the question of the default room by the accuracy instead of the order, only for classic and async mode.")
+	seed       = flag.Int64("seed", 0, "seed of the adaptive difficulty and the draw, the same seed produce the same questions. zero is random.")
+	target     = flag.Float64("target-accuracy", usecase.DefaultTargetAccuracy, "success rate aimed by the adaptive difficulty.")
+	tourneys   = flag.String("tournaments", "", "directory for saving the tournaments is optional, the tournaments are kept in memory if empty.")
+	bank       = flag.String("questions", "", "question bank file or directory of json files is optional, the default questions are used if empty. the bank is reloaded when the files are changed.")
+	draw       = flag.String("draw", "", "draw rule of the default room, e.g. science:4,history:3,random:3 or #tag:2. the questions and options are shuffled.")
+	shuffle    = flag.Bool("shuffle", false, "shuffle the questions and options of the default room without draw rule.")
+	noRepeat   = flag.Int("no-repeat", 0, "exclude the question drawn in the last N games.")
+	rounds     = flag.Int("rounds", 0, "total round of the default room, zero is every question. in elimination mode the question which is not asked is kept for the sudden death, zero keep the last 3 questions, at most half of the questions.")
+	review     = flag.Bool("review", false, "hold the default room after each round until the host type /next.")
+	bots       = flag.Int("bots", 0, "total bots joined to the default room, the bot is tagged in the leaderboard and not rated.")
+	botAcc     = flag.String("bot-accuracy", usecase.DefaultBotAccuracy, "chance of the correct answer of the bot, e.g. 0.7 or 0.5-0.9 for a different accuracy of each bot.")
+	botDelay   = flag.String("bot-reaction", "", "delay of the bot answer, fixed:2s, uniform:1s-5s, normal:3s,1s or exp:2s. empty is random time before the deadline.")
+	report     = flag.String("report", "", "directory of the report is optional, the report of every finished game is written if exist. e.g. reports")
+	reportFmt  = flag.String("report-format", "", "comma separated format of the report, csv, json or md. empty is every format.")
+	eventLog   = flag.String("event-log", "", "directory of the event log is optional, every room is recorded as jsonl for quiz replay if exist. e.g. logs")
+)
 
 type runner interface {
 	Start(context.Context) error
 }
 
-func main() {
-	flag.Parse()
+// commands is the sub command of quiz, e.g. quiz present -r room-1
+var commands = map[string]func(args []string) (runner, error){
+	"present":    presentCommand,
+	"practice":   practiceCommand,
+	"duel":       duelCommand,
+	"tournament": tournamentCommand,
+	"import":     importCommand,
+	"questions":  questionsCommand,
+	"loadtest":   loadtestCommand,
+	"replay":     replayCommand,
+}
 
+func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	sig := make(chan os.Signal, 1)
 	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
@@ -32,10 +78,44 @@ func main() {
 		cancel()
 	}()
 
-	// default mode is client mode
-	var Runner runner = server.NewServer()
-	if *player != "" {
-		Runner = client.NewClient(*player)
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			Runner, err := command(os.Args[2:])
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if err = Runner.Start(ctx); err != nil {
+				log.Fatal(err)
+			}
+
+			return
+		}
+	}
+
+	flag.Parse()
+
+	// default mode is client mode, the server is only created when the player is not set
+	var Runner runner
+	switch {
+	case *spectate:
+		Runner = client.NewSpectator(*player, *room, *delay)
+	case *player != "":
+		Runner = client.NewClient(*player, *room, *team)
+	default:
+		srv, err := newServer()
+		if err != nil {
+			log.Fatal(err)
+		}
+		Runner = srv
+
+		if *httpAddr != "" {
+			go func() {
+				if err := gateway.NewGateway(srv, *httpAddr).Start(ctx); err != nil {
+					log.Println(err)
+				}
+			}()
+		}
 	}
 
 	// start the runner
@@ -43,3 +123,93 @@ func main() {
 		log.Fatal(err)
 	}
 }
+
+// newServer create the server and the default room from the flags
+func newServer() (*server.Server, error) {
+	teamPolicy := usecase.AverageScore
+	if *policy == "captain" {
+		teamPolicy = usecase.CaptainAnswer
+	}
+
+	var teams []string
+	if *team != "" {
+		teams = strings.Split(*team, ",")
+	}
+
+	limit, err := usecase.ParseLifelines(*lifelines)
+	if err != nil {
+		return nil, err
+	}
+
+	reveal, err := usecase.ParseHintReveal(*hintReveal)
+	if err != nil {
+		return nil, err
+	}
+
+	var adaptiveCfg *usecase.AdaptiveConfig
+	if *adaptive {
+		adaptiveCfg = &usecase.AdaptiveConfig{Target: *target, Seed: *seed}
+	}
+
+	var questions []usecase.QuestionPayload
+	var reloader *usecase.BankReloader
+	if *bank != "" {
+		if reloader, err = usecase.NewBankReloader(*bank); err != nil {
+			return nil, err
+		}
+		questions = reloader.Questions()
+	}
+
+	rules, err := usecase.ParseDrawRules(*draw)
+	if err != nil {
+		return nil, err
+	}
+
+	var drawCfg *usecase.DrawConfig
+	if len(rules) > 0 || *shuffle || *noRepeat > 0 {
+		drawCfg = &usecase.DrawConfig{Rules: rules, NoRepeat: *noRepeat, Seed: *seed}
+	}
+
+	botCfg, err := usecase.ParseBotConfig(*bots, *botAcc, *botDelay)
+	if err != nil {
+		return nil, err
+	}
+	botCfg.Seed = *seed
+
+	formats, err := usecase.ParseReportFormats(*reportFmt)
+	if err != nil {
+		return nil, err
+	}
+
+	srv, err := server.NewServer(usecase.RoomConfig{
+		Mode:       *mode,
+		Teams:      teams,
+		TeamPolicy: teamPolicy,
+		TimeLimit:  *timeLimit,
+		ClosesAt:   time.Now().Add(*closesIn),
+		TotalRound: *rounds,
+		Lifelines:  limit,
+		Hints: usecase.HintConfig{
+			Mode:   *hintMode,
+			Cost:   *hintCost,
+			Reveal: reveal,
+		},
+		Adaptive:  adaptiveCfg,
+		Questions: questions,
+		Draw:      drawCfg,
+		Review:    *review,
+		Bots:      botCfg,
+	})
+	if err != nil {
+		return nil, err
+	}
+	srv.SpectatorDelay = *delay
+	srv.HostKey = *hostKey
+	srv.TournamentDir = *tourneys
+	srv.Bank = reloader
+	srv.Lobby.LogDir = *eventLog
+	srv.Lobby.ReportDir = *report
+	srv.Lobby.ReportFormats = formats
+
+	return srv, nil
+}
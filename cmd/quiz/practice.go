@@ -0,0 +1,23 @@
+package main
+
+import (
+	"errors"
+	"flag"
+
+	practice "github.com/elangreza14/grpc-quiz/cmd/practice"
+)
+
+func practiceCommand(args []string) (runner, error) {
+	fs := flag.NewFlagSet("practice", flag.ExitOnError)
+	player := fs.String("p", "", "player name is required.")
+	addr := fs.String("addr", "", "address of the server is optional, e.g. :50051. the practice is played in-process if empty.")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if *player == "" {
+		return nil, errors.New("player name is required")
+	}
+
+	return practice.NewPractice(*player, *addr), nil
+}
@@ -0,0 +1,18 @@
+package main
+
+import (
+	"flag"
+
+	present "github.com/elangreza14/grpc-quiz/cmd/present"
+)
+
+func presentCommand(args []string) (runner, error) {
+	fs := flag.NewFlagSet("present", flag.ExitOnError)
+	room := fs.String("r", "", "room is optional, present the default room if empty.")
+	hostKey := fs.String("host-key", "", "host key of the server is optional, if exist present as host without delay and (Enter) will start the game.")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	return present.NewPresenter(*room, *hostKey), nil
+}
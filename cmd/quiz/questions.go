@@ -0,0 +1,37 @@
+package main
+
+import (
+	"errors"
+	"flag"
+
+	questions "github.com/elangreza14/grpc-quiz/cmd/questions"
+	"github.com/elangreza14/grpc-quiz/internal/usecase"
+)
+
+// questionsCommand is quiz questions lint|stats|new|preview questions/general.json
+func questionsCommand(args []string) (runner, error) {
+	if len(args) == 0 {
+		return nil, errors.New("questions action is required: lint, stats, new or preview")
+	}
+
+	cmd := questions.NewCommand(args[0])
+
+	fs := flag.NewFlagSet("questions "+args[0], flag.ExitOnError)
+	fs.BoolVar(&cmd.Strict, "strict", false, "fail the lint on warning.")
+	fs.BoolVar(&cmd.Shuffle, "shuffle", false, "shuffle the questions and options of the preview.")
+	fs.Int64Var(&cmd.Seed, "seed", 0, "seed of the shuffle and the draw of the preview. zero is random.")
+	fs.StringVar(&cmd.Player, "p", "author", "player name of the preview.")
+	draw := fs.String("draw", "", "draw rule of the preview, e.g. science:4,random:3.")
+	if err := fs.Parse(args[1:]); err != nil {
+		return nil, err
+	}
+	cmd.Path = fs.Arg(0)
+
+	rules, err := usecase.ParseDrawRules(*draw)
+	if err != nil {
+		return nil, err
+	}
+	cmd.Draw = rules
+
+	return cmd, nil
+}
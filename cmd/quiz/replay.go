@@ -0,0 +1,35 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"time"
+
+	replay "github.com/elangreza14/grpc-quiz/cmd/replay"
+)
+
+// replayCommand is quiz replay -speed 2 -serve logs/default-20240101-090000.jsonl
+func replayCommand(args []string) (runner, error) {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	speed := fs.Float64("speed", 0, "pace of the replay, 1 is the recorded pace and 2 is twice faster. zero replay as fast as possible.")
+	serve := fs.Bool("serve", false, "serve the replay on :50051 for the spectator, e.g. quiz -spectate -r room-1 or quiz present.")
+	wait := fs.Duration("wait", 5*time.Second, "time for the spectator to join before the replay is started, only used with -serve.")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if fs.NArg() != 1 {
+		return nil, errors.New("event log file is required")
+	}
+
+	if *speed < 0 {
+		return nil, errors.New("speed must not be negative")
+	}
+
+	return replay.NewReplayer(replay.Config{
+		Path:  fs.Arg(0),
+		Speed: *speed,
+		Serve: *serve,
+		Wait:  *wait,
+	}), nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"strings"
+
+	tournament "github.com/elangreza14/grpc-quiz/cmd/tournament"
+)
+
+// tournamentCommand is quiz tournament create|list|start|bracket|play
+func tournamentCommand(args []string) (runner, error) {
+	if len(args) == 0 {
+		return nil, errors.New("tournament action is required: create, list, start, bracket or play")
+	}
+
+	cmd := tournament.NewCommand(args[0])
+
+	fs := flag.NewFlagSet("tournament "+args[0], flag.ExitOnError)
+	fs.StringVar(&cmd.ID, "id", "", "id of the tournament.")
+	fs.StringVar(&cmd.Name, "name", "", "name of the new tournament.")
+	fs.StringVar(&cmd.Format, "format", "single-elimination", "format of the new tournament, single-elimination or round-robin.")
+	fs.StringVar(&cmd.Player, "p", "", "player name for playing the tournament.")
+	fs.BoolVar(&cmd.Watch, "watch", false, "render the bracket again until the tournament is done.")
+	players := fs.String("players", "", "comma separated players of the new tournament. e.g. ann,bob,carl")
+	if err := fs.Parse(args[1:]); err != nil {
+		return nil, err
+	}
+
+	if *players != "" {
+		cmd.Players = strings.Split(*players, ",")
+	}
+
+	return cmd, nil
+}
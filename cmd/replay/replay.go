@@ -0,0 +1,122 @@
+// Package replay ....
+package replay
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"time"
+
+	server "github.com/elangreza14/grpc-quiz/cmd/server"
+	"github.com/elangreza14/grpc-quiz/internal/usecase"
+	quiz "github.com/elangreza14/grpc-quiz/proto"
+	"google.golang.org/grpc"
+)
+
+type (
+	// Config of the replay
+	Config struct {
+		Path string
+		// Speed is the pace of the replay, 1 is the recorded pace. zero is as fast as possible
+		Speed float64
+		// Serve stream the replay to the spectator on :50051, Wait is the time for the spectator to join
+		Serve bool
+		Wait  time.Duration
+	}
+
+	// Replayer run the game of the event log again and check the final scores
+	Replayer struct {
+		cfg Config
+		out io.Writer
+	}
+
+	// spectatorServer only serve Spectate, the replayed room can't be joined by the player
+	spectatorServer struct {
+		srv *server.Server
+		quiz.UnimplementedQuizServer
+	}
+)
+
+// NewReplayer is ...
+func NewReplayer(cfg Config) *Replayer {
+	return &Replayer{
+		cfg: cfg,
+		out: os.Stdout,
+	}
+}
+
+// Start is ...
+func (r *Replayer) Start(ctx context.Context) error {
+	entries, err := usecase.LoadEventLog(r.cfg.Path)
+	if err != nil {
+		return err
+	}
+
+	replay, err := usecase.NewReplay(entries)
+	if err != nil {
+		return err
+	}
+	replay.Speed = r.cfg.Speed
+
+	if r.cfg.Serve {
+		stop, err := r.serve(ctx, replay)
+		if err != nil {
+			return err
+		}
+		defer stop()
+	}
+
+	result, err := replay.Run(ctx)
+	if r.cfg.Serve {
+		replay.Room.ShutdownClient()
+	}
+
+	fmt.Fprintln(r.out, "=== replayed point ===")
+	for _, score := range result.Scores {
+		fmt.Fprintf(r.out, "player: %v point %v\n", score.Name, score.Point)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if result.Recorded == nil {
+		fmt.Fprintln(r.out, "the log has no final scores, the game is replayed until the last line")
+		return nil
+	}
+
+	fmt.Fprintln(r.out, "the final scores are the same as the log")
+	return nil
+}
+
+// serve the replayed room for the spectator, the replay is started after the wait
+func (r *Replayer) serve(ctx context.Context, replay *usecase.Replay) (func(), error) {
+	listener, err := net.Listen("tcp", ":50051")
+	if err != nil {
+		return nil, err
+	}
+
+	srv := grpc.NewServer()
+	quiz.RegisterQuizServer(srv, spectatorServer{srv: &server.Server{Lobby: replay.Lobby()}})
+	go func() {
+		_ = srv.Serve(listener)
+	}()
+
+	fmt.Fprintf(r.out, "replay of room %s is served on :50051, watch with quiz -spectate -r %s\n", replay.Room.ID, replay.Room.ID)
+	if r.cfg.Wait > 0 {
+		fmt.Fprintf(r.out, "the replay is started in %s\n", r.cfg.Wait)
+		select {
+		case <-ctx.Done():
+		case <-time.After(r.cfg.Wait):
+		}
+	}
+
+	return srv.GracefulStop, nil
+}
+
+// Spectate is ...
+func (s spectatorServer) Spectate(req *quiz.SpectateRequest, stream quiz.Quiz_SpectateServer) error {
+	return s.srv.Spectate(req, stream)
+}
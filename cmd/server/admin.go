@@ -0,0 +1,165 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elangreza14/grpc-quiz/internal/usecase"
+	quiz "github.com/elangreza14/grpc-quiz/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Admin is the grpc service for the host of the server
+type Admin struct {
+	server *Server
+
+	quiz.UnimplementedAdminServer
+}
+
+// Admin return the admin service of the server
+func (s *Server) Admin() *Admin {
+	return &Admin{server: s}
+}
+
+// GetBank is handler for the status of the question bank
+func (a *Admin) GetBank(_ context.Context, req *quiz.AdminRequest) (*quiz.BankStatus, error) {
+	if err := a.authorize(req.HostKey); err != nil {
+		return nil, err
+	}
+
+	if a.server.Bank == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "the server has no question bank, start the server with -questions")
+	}
+
+	return toBankStatus(a.server.Bank.Status()), nil
+}
+
+// ReloadBank is handler for reloading the question bank now
+func (a *Admin) ReloadBank(_ context.Context, req *quiz.AdminRequest) (*quiz.BankStatus, error) {
+	if err := a.authorize(req.HostKey); err != nil {
+		return nil, err
+	}
+
+	if a.server.Bank == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "the server has no question bank, start the server with -questions")
+	}
+
+	if err := a.server.Bank.Reload(); err != nil {
+		fmt.Printf("reload question bank failed, the old bank is kept: %v\n", err)
+	}
+
+	return toBankStatus(a.server.Bank.Status()), nil
+}
+
+// ControlRound is handler for the host to pause, resume, skip, extend or advance the round
+func (a *Admin) ControlRound(_ context.Context, req *quiz.ControlRoundRequest) (*quiz.GameState, error) {
+	if err := a.authorize(req.HostKey); err != nil {
+		return nil, err
+	}
+
+	room, ok := a.server.Lobby.GetRoom(req.Room)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "room not found")
+	}
+
+	if err := room.Control(usecase.RoundControl(req.Action), req.Extend.AsDuration()); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	return toGameState(room), nil
+}
+
+// AddBots is handler for the host to fill the room with the bots
+func (a *Admin) AddBots(_ context.Context, req *quiz.AddBotsRequest) (*quiz.AddBotsResponse, error) {
+	if err := a.authorize(req.HostKey); err != nil {
+		return nil, err
+	}
+
+	room, ok := a.server.Lobby.GetRoom(req.Room)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "room not found")
+	}
+
+	cfg, err := usecase.ParseBotConfig(int(req.Count), req.Accuracy, req.Reaction)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	bots, err := room.AddBots(cfg)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	fmt.Printf("room %s: %d bots joined\n", room.ID, len(bots))
+
+	return &quiz.AddBotsResponse{Bots: bots}, nil
+}
+
+// ExportResult is handler for the report of the last finished game of the room
+func (a *Admin) ExportResult(_ context.Context, req *quiz.ExportResultRequest) (*quiz.ExportResultResponse, error) {
+	if err := a.authorize(req.HostKey); err != nil {
+		return nil, err
+	}
+
+	room := req.Room
+	if room == "" {
+		room = usecase.DefaultRoom
+	}
+
+	results := a.server.Lobby.Results.List(room)
+	if len(results) == 0 {
+		return nil, status.Errorf(codes.NotFound, "the room has no finished game")
+	}
+
+	format := req.Format
+	if format == "" {
+		format = usecase.ReportMarkdown
+	}
+
+	files, err := usecase.NewReport(results[len(results)-1]).Files(format)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	res := &quiz.ExportResultResponse{}
+	for _, file := range files {
+		res.Files = append(res.Files, &quiz.ReportFile{
+			Name:    file.Name,
+			Content: string(file.Data),
+		})
+	}
+
+	return res, nil
+}
+
+// authorize is closed when the server has no host key
+func (a *Admin) authorize(key string) error {
+	if a.server.HostKey == "" {
+		return status.Errorf(codes.PermissionDenied, "admin is disabled, start the server with the host key")
+	}
+
+	if !a.server.isHost(key) {
+		return status.Errorf(codes.PermissionDenied, "host key is invalid")
+	}
+
+	return nil
+}
+
+func toBankStatus(bank usecase.BankStatus) *quiz.BankStatus {
+	res := &quiz.BankStatus{
+		Path:      bank.Path,
+		Name:      bank.Name,
+		Version:   int32(bank.Version),
+		Questions: int32(bank.Questions),
+		LoadedAt:  timestamppb.New(bank.LoadedAt),
+		Errors:    bank.Errors,
+	}
+
+	if !bank.FailedAt.IsZero() {
+		res.FailedAt = timestamppb.New(bank.FailedAt)
+	}
+
+	return res
+}
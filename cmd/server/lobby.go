@@ -0,0 +1,188 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elangreza14/grpc-quiz/internal/usecase"
+	quiz "github.com/elangreza14/grpc-quiz/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateRoom is handler for creating new room
+func (s *Server) CreateRoom(_ context.Context, req *quiz.CreateRoomRequest) (*quiz.Room, error) {
+	counts := map[string]int{}
+	for name, count := range req.Lifelines {
+		counts[name] = int(count)
+	}
+
+	lifelines, err := usecase.LifelineLimit(counts)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	cfg := usecase.RoomConfig{
+		Name:       req.Name,
+		Mode:       req.Mode,
+		Teams:      req.Teams,
+		TeamPolicy: usecase.TeamPolicy(req.TeamPolicy),
+		TimeLimit:  req.TimeLimit.AsDuration(),
+		Lifelines:  lifelines,
+		Review:     req.Review,
+		Hints: usecase.HintConfig{
+			Mode:   req.GetHints().GetMode(),
+			Cost:   int(req.GetHints().GetCost()),
+			Reveal: req.GetHints().GetReveal(),
+		},
+	}
+	// the practice room is only for the session of one player, it is removed from the lobby when the practice is done
+	cfg.Temporary = req.Mode == usecase.PracticeMode
+	if req.ClosesAt != nil {
+		cfg.ClosesAt = req.ClosesAt.AsTime()
+	}
+	if req.Adaptive != nil {
+		cfg.Adaptive = &usecase.AdaptiveConfig{
+			Target: req.Adaptive.Target,
+			Seed:   req.Adaptive.Seed,
+		}
+	}
+	if req.Draw != nil {
+		cfg.Draw = &usecase.DrawConfig{
+			NoRepeat: int(req.Draw.NoRepeat),
+			Seed:     req.Draw.Seed,
+		}
+		for _, rule := range req.Draw.Rules {
+			cfg.Draw.Rules = append(cfg.Draw.Rules, usecase.DrawRule{
+				Category: rule.Category,
+				Tag:      rule.Tag,
+				Count:    int(rule.Count),
+			})
+		}
+	}
+
+	room, err := s.Lobby.CreateRoom(cfg)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	fmt.Printf("room %s created\n", room.ID)
+
+	return toRoom(room), nil
+}
+
+// ListRooms is handler for listing all the room
+func (s *Server) ListRooms(context.Context, *quiz.ListRoomsRequest) (*quiz.ListRoomsResponse, error) {
+	res := &quiz.ListRoomsResponse{}
+	for _, room := range s.Lobby.ListRooms() {
+		res.Rooms = append(res.Rooms, toRoom(room))
+	}
+
+	return res, nil
+}
+
+// StartGame is handler for starting the game in the room
+func (s *Server) StartGame(_ context.Context, req *quiz.RoomRequest) (*quiz.Message, error) {
+	room, ok := s.Lobby.GetRoom(req.Room)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "room not found")
+	}
+
+	if _, ok := room.Game.(*usecase.AsyncGame); ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s room is started when the player join", usecase.AsyncMode)
+	}
+
+	if room.Started() {
+		return nil, status.Errorf(codes.FailedPrecondition, "game already started")
+	}
+
+	if room.TotalPlayer() == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "no player joined")
+	}
+
+	room.PublishQueue(&usecase.Event{
+		EventType: usecase.StartGame,
+	})
+
+	return &quiz.Message{
+		Message: fmt.Sprintf("game in room %s started", room.ID),
+	}, nil
+}
+
+// GetState is handler for getting current state of the game in the room
+func (s *Server) GetState(_ context.Context, req *quiz.RoomRequest) (*quiz.GameState, error) {
+	room, ok := s.Lobby.GetRoom(req.Room)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "room not found")
+	}
+
+	return toGameState(room), nil
+}
+
+func toGameState(room *usecase.Room) *quiz.GameState {
+	snapshot := room.Snapshot()
+
+	return &quiz.GameState{
+		Room:       room.ID,
+		Mode:       snapshot.Mode,
+		State:      toState(snapshot.State),
+		Round:      int32(snapshot.Round),
+		TotalRound: int32(snapshot.TotalRound),
+		Scores:     usecase.ProtoScores(snapshot.Scores),
+		Teams:      usecase.ProtoTeams(snapshot.Teams),
+		Paused:     snapshot.Paused,
+		Review:     snapshot.Review,
+	}
+}
+
+// GetHistory is handler for getting the result of finished games
+func (s *Server) GetHistory(_ context.Context, req *quiz.GetHistoryRequest) (*quiz.GetHistoryResponse, error) {
+	res := &quiz.GetHistoryResponse{}
+	for _, result := range s.Lobby.Results.List(req.Room) {
+		res.Results = append(res.Results, &quiz.GameResult{
+			Room:       result.Room,
+			FinishedAt: timestamppb.New(result.FinishedAt),
+			Scores:     usecase.ProtoScores(result.Scores),
+			Teams:      usecase.ProtoTeams(result.Teams),
+			Lifelines:  usecase.ProtoLifelines(result.Lifelines),
+		})
+	}
+
+	return res, nil
+}
+
+func toRoom(room *usecase.Room) *quiz.Room {
+	snapshot := room.Snapshot()
+	res := &quiz.Room{
+		Id:          room.ID,
+		Name:        room.Name,
+		Mode:        snapshot.Mode,
+		State:       toState(snapshot.State),
+		TotalPlayer: int32(room.TotalPlayer()),
+		CreatedAt:   timestamppb.New(room.CreatedAt),
+		Seed:        room.Seed,
+	}
+
+	if game, ok := room.Game.(*usecase.AsyncGame); ok {
+		res.ClosesAt = timestamppb.New(game.ClosesAt())
+	}
+
+	if room.Teams != nil {
+		res.Teams = room.Teams.Names()
+		res.TeamPolicy = quiz.TeamPolicy(room.Teams.Policy)
+	}
+
+	return res
+}
+
+func toState(state usecase.State) quiz.GameState_State {
+	switch state {
+	case usecase.OnProgress:
+		return quiz.GameState_ON_PROGRESS
+	case usecase.Done:
+		return quiz.GameState_DONE
+	default:
+		return quiz.GameState_WAITING
+	}
+}
@@ -0,0 +1,46 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/elangreza14/grpc-quiz/internal/usecase"
+	quiz "github.com/elangreza14/grpc-quiz/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FindMatch is handler for 1v1 duel matchmaking
+func (s *Server) FindMatch(ctx context.Context, req *quiz.FindMatchRequest) (*quiz.Match, error) {
+	if req.Player == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "player is required")
+	}
+
+	timeout := req.Timeout.AsDuration()
+	if timeout <= 0 {
+		timeout = usecase.DefaultMatchTimeout
+	}
+
+	match, err := s.Lobby.Matchmaker.FindMatch(ctx, req.Player, timeout, req.BotFallback)
+	switch {
+	case errors.Is(err, usecase.ErrNoOpponent):
+		return nil, status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, usecase.ErrAlreadyQueued):
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return nil, status.FromContextError(err).Err()
+	case err != nil:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	fmt.Printf("player %s matched with %s in room %s\n", req.Player, match.Opponent, match.Room)
+
+	return &quiz.Match{
+		Room:           match.Room,
+		Opponent:       match.Opponent,
+		Bot:            match.Bot,
+		Rating:         int32(match.Rating),
+		OpponentRating: int32(match.OpponentRating),
+	}, nil
+}
@@ -3,10 +3,13 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/elangreza14/grpc-quiz/internal/usecase"
 	quiz "github.com/elangreza14/grpc-quiz/proto"
@@ -19,39 +22,84 @@ import (
 type (
 	// Server is default structure for creating communication
 	Server struct {
+		Lobby    *usecase.Lobby
 		Room     *usecase.Room
 		Terminal *usecase.Terminal
 		PowerOff chan bool
+		// SpectatorDelay is the minimum delay of the event for the spectator
+		SpectatorDelay time.Duration
+		// HostKey is the key for the host to spectate without delay
+		HostKey string
+		// Tournaments is loaded from TournamentDir when the server is started
+		Tournaments   *usecase.Tournaments
+		TournamentDir string
+		// Bank is reloaded when the question files are changed, nil mean the server has no question bank
+		Bank *usecase.BankReloader
 
 		quiz.UnimplementedQuizServer
 	}
 )
 
-// NewServer define a grpc server
-func NewServer() *Server {
+// NewServer define a grpc server, the config is used for the default room
+func NewServer(cfg usecase.RoomConfig) (*Server, error) {
+	lobby, err := usecase.NewLobby(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	room, _ := lobby.GetRoom(usecase.DefaultRoom)
+	tournaments, _ := usecase.NewTournaments(lobby, "")
+
 	return &Server{
-		Room:                    usecase.NewRoom(),
-		Terminal:                usecase.NewTerminal(),
+		Lobby:                   lobby,
+		Room:                    room,
+		Tournaments:             tournaments,
+		Terminal:                usecase.NewTerminal(os.Stdin),
 		PowerOff:                make(chan bool),
 		UnimplementedQuizServer: quiz.UnimplementedQuizServer{},
-	}
+	}, nil
 }
 
 // Start is gateway to grpc server
 func (s *Server) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", ":50051")
+	if err != nil {
+		return err
+	}
+
+	return s.Serve(ctx, listener)
+}
+
+// Serve the grpc server on the listener until ctx is done or the game of the default room is finished
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	// the saved tournaments is only resumed by the running server
+	if s.TournamentDir != "" {
+		tournaments, err := usecase.NewTournaments(s.Lobby, s.TournamentDir)
+		if err != nil {
+			return err
+		}
+		s.Tournaments = tournaments
+	}
+
+	// the room which is not started get the questions of the reloaded bank
+	if s.Bank != nil {
+		s.Bank.OnReload = s.Lobby.SetBank
+		go s.Bank.Watch(ctx, usecase.DefaultReloadInterval)
+	}
+
 	srv := grpc.NewServer()
 	quiz.RegisterQuizServer(srv, s)
+	quiz.RegisterAdminServer(srv, s.Admin())
 
 	// listen all the event
-	go s.Room.ListenQueue(ctx)
-	go s.listenTerminal(ctx)
-
-	listener, err := net.Listen("tcp", ":50051")
-	if err != nil {
-		return err
+	go s.Lobby.ListenRooms(ctx)
+	if game, ok := s.Room.Game.(*usecase.AsyncGame); ok {
+		fmt.Printf("self-paced quiz is open until %s\n", game.ClosesAt().Format(time.TimeOnly))
+	} else {
+		go s.listenTerminal(ctx)
 	}
 
 	go func() {
@@ -62,13 +110,16 @@ func (s *Server) Start(ctx context.Context) error {
 	select {
 	case <-ctx.Done():
 		break
-	case <-s.Room.PowerOff:
-		break
+	case <-s.Room.Done():
+		// the report of the default room is written before the server is stopped
+		<-s.Room.Saved()
 	}
 
 	fmt.Println("shutting down the server")
 
-	s.Room.ShutdownClient()
+	for _, room := range s.Lobby.ListRooms() {
+		room.ShutdownClient()
+	}
 
 	srv.GracefulStop()
 
@@ -77,18 +128,36 @@ func (s *Server) Start(ctx context.Context) error {
 
 // Register is handler for register player
 func (s *Server) Register(_ context.Context, req *quiz.RegisterRequest) (*quiz.Message, error) {
-	_, ok := s.Room.GetPlayerDetail(req.Player)
-	if ok {
-		return nil, status.Errorf(codes.AlreadyExists, "player already exist")
+	if req.Player == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "player is required")
 	}
 
-	s.Room.PublishQueue(&usecase.Event{
-		EventType: usecase.InsertPlayer,
-		Payload:   req.Player,
-	})
+	room, ok := s.Lobby.GetRoom(req.Room)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "room not found")
+	}
+
+	if !room.CanJoin(req.Player) {
+		return nil, status.Errorf(codes.PermissionDenied, "room is not open for the player")
+	}
+
+	team, err := room.Join(req.Player, req.Team)
+	if errors.Is(err, usecase.ErrPlayerExists) {
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	message := fmt.Sprintf("hi %v, welcome to the game", req.Player)
+	if team != "" {
+		message = fmt.Sprintf("%s. you are in team %s", message, team)
+		if room.Teams.Policy == usecase.CaptainAnswer && room.Teams.IsCaptain(req.Player) {
+			message = fmt.Sprintf("%s as the captain", message)
+		}
+	}
 
 	return &quiz.Message{
-		Message: fmt.Sprintf("hi %v, welcome to the game", req.Player),
+		Message: message,
 	}, nil
 }
 
@@ -104,22 +173,37 @@ func (s *Server) Stream(stream quiz.Quiz_StreamServer) error {
 		return status.Errorf(codes.Unauthenticated, "player not found")
 	}
 
-	streamPlayer, ok := s.Room.GetPlayerDetail(player[0])
+	var roomID string
+	if val := md.Get("room"); len(val) > 0 {
+		roomID = val[0]
+	}
+
+	room, ok := s.Lobby.GetRoom(roomID)
+	if !ok {
+		return status.Errorf(codes.NotFound, "room not found")
+	}
+
+	streamPlayer, ok := room.GetPlayerDetail(player[0])
 	if !ok {
 		return status.Errorf(codes.Unauthenticated, "player not found")
 	}
 
 	defer func() {
+		room.Leave(player[0], streamPlayer)
 		close(streamPlayer)
-		s.Room.RemovePlayer(player[0])
-		fmt.Printf("player %s left. total %d players \n", player[0], s.Room.TotalPlayer())
+		fmt.Printf("player %s left. total %d players \n", player[0], room.TotalPlayer())
 	}()
 
+	// the header tell the client the stream is accepted before the first event
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
 	// send stream from server
 	go s.streamSend(stream, streamPlayer)
 
 	// receive stream from client
-	return s.streamReceive(player[0], stream)
+	return s.streamReceive(room, player[0], stream)
 }
 
 func (s *Server) streamSend(stream quiz.Quiz_StreamServer, streamPlayer <-chan *quiz.StreamResponse) {
@@ -138,7 +222,7 @@ func (s *Server) streamSend(stream quiz.Quiz_StreamServer, streamPlayer <-chan *
 	}
 }
 
-func (s *Server) streamReceive(name string, stream quiz.Quiz_StreamServer) error {
+func (s *Server) streamReceive(room *usecase.Room, name string, stream quiz.Quiz_StreamServer) error {
 	for {
 		req, err := stream.Recv()
 		if err != nil {
@@ -147,37 +231,75 @@ func (s *Server) streamReceive(name string, stream quiz.Quiz_StreamServer) error
 			}
 			return err
 		}
+		receivedAt := time.Now()
+
+		// team chat is private for the member of the team
+		if text, ok := strings.CutPrefix(req.Message, "/t "); ok {
+			room.PublishQueue(&usecase.Event{
+				EventType: usecase.TeamChat,
+				Payload: usecase.TeamChatPayload{
+					Name:    name,
+					Message: text,
+				},
+			})
+			continue
+		}
 
 		// if game not yet started
-		if !s.Room.Started {
-			s.Room.PublishQueue(&usecase.Event{
+		if !room.Started() {
+			room.PublishQueue(&usecase.Event{
 				EventType: usecase.Broadcast,
 				Payload:   req.Message,
 			})
 			continue
 		}
 
-		msg := strings.ToLower(req.Message)
-		if msg == "y" || msg == "n" {
-			s.Room.PublishQueue(&usecase.Event{
-				EventType: usecase.SubmitAnswer,
-				Payload: usecase.SubmitAnswerPayload{
-					Name:   name,
-					Answer: msg == "y",
+		msg := strings.ToLower(strings.TrimSpace(req.Message))
+		if msg == "/hint" {
+			room.PublishQueue(&usecase.Event{
+				EventType: usecase.RequestHint,
+				Payload:   name,
+			})
+		} else if lifeline, ok := strings.CutPrefix(msg, "/"); ok {
+			room.PublishQueue(&usecase.Event{
+				EventType: usecase.UseLifeline,
+				Payload: usecase.LifelinePayload{
+					Name:     name,
+					Lifeline: lifeline,
 				},
 			})
-		} else {
-			s.Room.PublishQueue(&usecase.Event{
+		} else if err := room.Game.CanAnswer(name); msg == "b" && errors.Is(err, usecase.ErrNotBuzzed) {
+			// the buzz is ordered by the time it is received, not the time it is processed.
+			// The holder of the buzzer answer option B with the same key
+			room.PublishQueue(&usecase.Event{
+				EventType: usecase.Buzz,
+				Payload: usecase.BuzzPayload{
+					Name:       name,
+					ReceivedAt: receivedAt,
+				},
+			})
+		} else if err != nil {
+			room.PublishQueue(&usecase.Event{
 				EventType: usecase.BroadcastPersonal,
 				Payload: usecase.BroadcastPersonalPayload{
 					Name:    name,
-					Message: "only accept (Y/N) when game is started",
+					Message: err.Error(),
+				},
+			})
+		} else {
+			// the answer is validated by the game, the option is different for each question
+			room.PublishQueue(&usecase.Event{
+				EventType: usecase.SubmitAnswer,
+				Payload: usecase.SubmitAnswerPayload{
+					Name:   name,
+					Answer: strings.ToUpper(msg),
 				},
 			})
 		}
 	}
 }
 
+// listenTerminal start the game with (Y), then the host control the round with /pause, /resume, /skip, /extend and /next
 func (s *Server) listenTerminal(ctx context.Context) {
 	fmt.Println("Waiting players to join. press (Y) to start")
 	for {
@@ -185,16 +307,32 @@ func (s *Server) listenTerminal(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		default:
-			decision, err := s.Terminal.ValBoolean()
-			if err != nil {
-				fmt.Println(err.Error())
+			text, ok := s.Terminal.ValText()
+			if !ok {
+				return
+			}
+
+			if strings.HasPrefix(text, "/") {
+				action, extend, err := usecase.ParseRoundControl(text)
+				if err == nil {
+					err = s.Room.Control(action, extend)
+				}
+				if err != nil {
+					fmt.Println(err.Error())
+				}
 				continue
 			}
 
-			if decision && s.Room.TotalPlayer() > 0 {
-				s.Room.PublishQueue(&usecase.Event{
-					EventType: usecase.StartGame,
-				})
+			switch strings.ToLower(strings.TrimSpace(text)) {
+			case "y":
+				if s.Room.TotalPlayer() > 0 {
+					s.Room.PublishQueue(&usecase.Event{
+						EventType: usecase.StartGame,
+					})
+				}
+			case "n":
+			default:
+				fmt.Println("terminal just accept Y / N, or /pause, /resume, /skip, /extend [seconds] and /next when the game is started")
 			}
 		}
 	}
@@ -0,0 +1,339 @@
+package server_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elangreza14/grpc-quiz/cmd/server"
+	"github.com/elangreza14/grpc-quiz/internal/usecase"
+	quiz "github.com/elangreza14/grpc-quiz/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// testTimeout fail the test when the game is stuck, the game with fast answer finish in a second
+const testTimeout = 10 * time.Second
+
+type (
+	// harness run the server on the bufconn listener, the host terminal is the pipe
+	harness struct {
+		t      *testing.T
+		srv    *server.Server
+		conn   *grpc.ClientConn
+		host   *io.PipeWriter
+		cancel context.CancelFunc
+		served chan error
+	}
+
+	// player is the scripted client, it answer each question with the next line of the script
+	player struct {
+		name     string
+		terminal *usecase.Terminal
+		stream   quiz.Quiz_StreamClient
+		events   []string
+		// scores is the final leaderboard, the score is removed from the room when the player left
+		scores map[string]int32
+		done   chan error
+	}
+)
+
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+
+	srv, err := server.NewServer(usecase.RoomConfig{})
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+
+	terminal, host := io.Pipe()
+	srv.Terminal = usecase.NewTerminal(terminal)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	listener := bufconn.Listen(1 << 20)
+	served := make(chan error, 1)
+	go func() {
+		served <- srv.Serve(ctx, listener)
+	}()
+
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		cancel()
+		t.Fatalf("DialContext() error = %v", err)
+	}
+
+	h := &harness{t: t, srv: srv, conn: conn, host: host, cancel: cancel, served: served}
+	t.Cleanup(func() {
+		cancel()
+		_ = host.Close()
+		_ = conn.Close()
+	})
+
+	return h
+}
+
+// join register the player and open the stream, the player is playing until the server shut down
+func (h *harness) join(name, script string) *player {
+	h.t.Helper()
+
+	client := quiz.NewQuizClient(h.conn)
+	ctx := context.Background()
+	if _, err := client.Register(ctx, &quiz.RegisterRequest{Player: name}); err != nil {
+		h.t.Fatalf("Register(%s) error = %v", name, err)
+	}
+
+	// the player is inserted by the room after the register, the stream of the unknown player is rejected
+	deadline := time.Now().Add(testTimeout)
+	for _, ok := h.srv.Room.GetPlayerDetail(name); !ok; _, ok = h.srv.Room.GetPlayerDetail(name) {
+		if time.Now().After(deadline) {
+			h.t.Fatalf("player %s is not inserted after %s", name, testTimeout)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx = metadata.NewOutgoingContext(ctx, metadata.New(map[string]string{"player": name}))
+	stream, err := client.Stream(ctx)
+	if err != nil {
+		h.t.Fatalf("Stream(%s) error = %v", name, err)
+	}
+
+	p := &player{
+		name:     name,
+		terminal: usecase.NewTerminal(strings.NewReader(script)),
+		stream:   stream,
+		done:     make(chan error, 1),
+	}
+	go func() {
+		p.done <- p.play()
+	}()
+
+	return p
+}
+
+// waitPlayers wait until the players is inserted to the default room
+func (h *harness) waitPlayers(total int) {
+	h.t.Helper()
+
+	deadline := time.Now().Add(testTimeout)
+	for h.srv.Room.TotalPlayer() != total {
+		if time.Now().After(deadline) {
+			h.t.Fatalf("total player = %d, want %d", h.srv.Room.TotalPlayer(), total)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// command type the line on the terminal of the host
+func (h *harness) command(line string) {
+	h.t.Helper()
+
+	if _, err := io.WriteString(h.host, line+"\n"); err != nil {
+		h.t.Fatalf("command %q error = %v", line, err)
+	}
+}
+
+// wait until Serve return
+func (h *harness) wait() error {
+	h.t.Helper()
+
+	select {
+	case err := <-h.served:
+		return err
+	case <-time.After(testTimeout):
+		h.t.Fatalf("the server is not shut down after %s", testTimeout)
+		return nil
+	}
+}
+
+func (p *player) play() error {
+	for {
+		res, err := p.stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		p.events = append(p.events, eventOf(res))
+		switch res.Event.(type) {
+		case *quiz.StreamResponse_Leaderboard:
+			if leaderboard := res.GetLeaderboard(); leaderboard.Final {
+				p.scores = map[string]int32{}
+				for _, score := range leaderboard.Scores {
+					p.scores[score.Player] = score.Point
+				}
+			}
+		case *quiz.StreamResponse_Question:
+			if answer, ok := p.terminal.ValText(); ok {
+				if err := p.stream.Send(&quiz.Message{Message: answer}); err != nil {
+					return err
+				}
+			}
+		case *quiz.StreamResponse_ServerShutdown:
+			return p.stream.CloseSend()
+		}
+	}
+}
+
+// wait until the player receive the shutdown
+func (p *player) wait(t *testing.T) []string {
+	t.Helper()
+
+	select {
+	case err := <-p.done:
+		if err != nil {
+			t.Fatalf("player %s error = %v", p.name, err)
+		}
+		return p.events
+	case <-time.After(testTimeout):
+		t.Fatalf("player %s is not finished after %s", p.name, testTimeout)
+		return nil
+	}
+}
+
+// eventOf is the short form of the event, the time of the event is ignored
+func eventOf(res *quiz.StreamResponse) string {
+	switch res.Event.(type) {
+	case *quiz.StreamResponse_ServerAnnouncement:
+		return "announcement: " + res.GetServerAnnouncement().Message
+	case *quiz.StreamResponse_Question:
+		question := res.GetQuestion()
+		return fmt.Sprintf("question %d/%d: %s", question.Round, question.TotalRound, question.Question)
+	case *quiz.StreamResponse_AnswerProgress:
+		progress := res.GetAnswerProgress()
+		return fmt.Sprintf("progress %d: %d/%d", progress.Round, progress.TotalAnswer, progress.TotalPlayer)
+	case *quiz.StreamResponse_RoundResult:
+		result := res.GetRoundResult()
+		return fmt.Sprintf("result %d: %s", result.Round, result.Answer)
+	case *quiz.StreamResponse_Leaderboard:
+		leaderboard := res.GetLeaderboard()
+		scores := []string{}
+		for _, score := range leaderboard.Scores {
+			scores = append(scores, fmt.Sprintf("%s=%d", score.Player, score.Point))
+		}
+		if leaderboard.Final {
+			return "final leaderboard: " + strings.Join(scores, " ")
+		}
+		return "leaderboard: " + strings.Join(scores, " ")
+	case *quiz.StreamResponse_ServerShutdown:
+		return "shutdown"
+	default:
+		return fmt.Sprintf("%T", res.Event)
+	}
+}
+
+func TestServerGame(t *testing.T) {
+	h := newHarness(t)
+
+	// the answer of the default questions is Y, N, Y and B, bob is wrong in the first and the third round
+	ann := h.join("ann", "Y\nN\nY\nB\n")
+	bob := h.join("bob", "N\nN\nN\nB\n")
+	h.waitPlayers(2)
+	h.command("y")
+
+	want := []string{
+		"announcement: game started",
+		"question 1/4: 1 + 1 = 2",
+		"progress 1: 1/2",
+		"progress 1: 2/2",
+		"result 1: Y",
+		"leaderboard: ann=1 bob=0",
+		"question 2/4: 1 - 1 = -1",
+		"progress 2: 1/2",
+		"progress 2: 2/2",
+		"result 2: N",
+		"leaderboard: ann=2 bob=1",
+		"question 3/4: 1 * 0 = 0",
+		"progress 3: 1/2",
+		"progress 3: 2/2",
+		"result 3: Y",
+		"leaderboard: ann=3 bob=1",
+		"question 4/4: 12 / 4 = ?",
+		"progress 4: 1/2",
+		"progress 4: 2/2",
+		"result 4: B",
+		"leaderboard: ann=4 bob=2",
+		"final leaderboard: ann=4 bob=2",
+		"announcement: game finished",
+		"shutdown",
+	}
+	for _, p := range []*player{ann, bob} {
+		if got := p.wait(t); !reflect.DeepEqual(got, want) {
+			t.Errorf("events of %s:\n%s\nwant:\n%s", p.name, strings.Join(got, "\n"), strings.Join(want, "\n"))
+		}
+
+		if want := map[string]int32{"ann": 4, "bob": 2}; !reflect.DeepEqual(p.scores, want) {
+			t.Errorf("scores of %s = %v, want %v", p.name, p.scores, want)
+		}
+	}
+
+	// the server is stopped by the end of the game
+	if err := h.wait(); err != nil {
+		t.Errorf("Serve() error = %v", err)
+	}
+}
+
+func TestServerShutdown(t *testing.T) {
+	h := newHarness(t)
+
+	ann := h.join("ann", "")
+	h.waitPlayers(1)
+	h.cancel()
+
+	if got, want := ann.wait(t), []string{"shutdown"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+
+	if err := h.wait(); err != nil {
+		t.Errorf("Serve() error = %v", err)
+	}
+
+	// the listener is closed by the graceful stop
+	client := quiz.NewQuizClient(h.conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := client.Register(ctx, &quiz.RegisterRequest{Player: "bob"}); err == nil {
+		t.Error("Register() after the shutdown error = nil, want error")
+	}
+}
+
+func TestAdminAuthorize(t *testing.T) {
+	tests := []struct {
+		name     string
+		hostKey  string
+		key      string
+		wantCode codes.Code
+	}{
+		{name: "the admin is disabled without the host key", wantCode: codes.PermissionDenied},
+		{name: "the invalid host key", hostKey: "secret", key: "guess", wantCode: codes.PermissionDenied},
+		{name: "the valid host key", hostKey: "secret", key: "secret", wantCode: codes.FailedPrecondition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, err := server.NewServer(usecase.RoomConfig{})
+			if err != nil {
+				t.Fatalf("NewServer() error = %v", err)
+			}
+			srv.HostKey = tt.hostKey
+
+			// the server without the bank fail the precondition after the key is authorized
+			_, err = srv.Admin().GetBank(context.Background(), &quiz.AdminRequest{HostKey: tt.key})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("GetBank() code = %s, want %s", got, tt.wantCode)
+			}
+		})
+	}
+}
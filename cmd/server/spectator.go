@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/elangreza14/grpc-quiz/internal/usecase"
+	quiz "github.com/elangreza14/grpc-quiz/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Spectate is handler for streaming the game to spectator.
+// Spectator is not counted as player and can't answer the question
+func (s *Server) Spectate(req *quiz.SpectateRequest, stream quiz.Quiz_SpectateServer) error {
+	room, ok := s.Lobby.GetRoom(req.Room)
+	if !ok {
+		return status.Errorf(codes.NotFound, "room not found")
+	}
+
+	name := req.Name
+	if name == "" {
+		name = "anonymous"
+	}
+
+	var (
+		events <-chan *quiz.StreamResponse
+		stop   func()
+	)
+
+	// the host is presenting the game to the player, it can't be delayed
+	if s.isHost(req.HostKey) {
+		events, stop = room.Watch()
+	} else {
+		events, stop = s.Watch(stream.Context(), room, req.Delay.AsDuration())
+	}
+	fmt.Printf("spectator %s joined. total %d spectators \n", name, room.TotalSpectator())
+
+	defer func() {
+		stop()
+		fmt.Printf("spectator %s left. total %d spectators \n", name, room.TotalSpectator())
+	}()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case evt, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.Send(evt); err != nil {
+				return err
+			}
+
+			if evt.GetServerShutdown() != nil {
+				return nil
+			}
+		}
+	}
+}
+
+func (s *Server) isHost(key string) bool {
+	return s.HostKey != "" && key == s.HostKey
+}
+
+// Watch return the event of the room for read-only consumer.
+// The event is delayed at least by the SpectatorDelay of the server
+func (s *Server) Watch(ctx context.Context, room *usecase.Room, delay time.Duration) (<-chan *quiz.StreamResponse, func()) {
+	if delay < s.SpectatorDelay {
+		delay = s.SpectatorDelay
+	}
+
+	events, stop := room.Watch()
+
+	return usecase.DelayEvent(ctx, events, delay), stop
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/elangreza14/grpc-quiz/internal/usecase"
+	quiz "github.com/elangreza14/grpc-quiz/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateTournament is handler for creating the tournament, the bracket is seeded when it is created
+func (s *Server) CreateTournament(_ context.Context, req *quiz.CreateTournamentRequest) (*quiz.Tournament, error) {
+	tournament, err := s.Tournaments.Create(usecase.TournamentConfig{
+		Name:    req.Name,
+		Format:  req.Format,
+		Players: req.Players,
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	fmt.Printf("tournament %s created\n", tournament.ID)
+
+	return toTournament(tournament), nil
+}
+
+// ListTournaments is ...
+func (s *Server) ListTournaments(context.Context, *quiz.ListTournamentsRequest) (*quiz.ListTournamentsResponse, error) {
+	res := &quiz.ListTournamentsResponse{}
+	for _, tournament := range s.Tournaments.List() {
+		res.Tournaments = append(res.Tournaments, toTournament(tournament))
+	}
+
+	return res, nil
+}
+
+// StartTournament is ...
+func (s *Server) StartTournament(_ context.Context, req *quiz.TournamentRequest) (*quiz.Tournament, error) {
+	tournament, err := s.Tournaments.Start(req.Tournament)
+	if err != nil {
+		return nil, tournamentError(err)
+	}
+
+	fmt.Printf("tournament %s started\n", tournament.ID)
+
+	return toTournament(tournament), nil
+}
+
+// GetBracket is ...
+func (s *Server) GetBracket(_ context.Context, req *quiz.TournamentRequest) (*quiz.Tournament, error) {
+	tournament, err := s.Tournaments.Get(req.Tournament)
+	if err != nil {
+		return nil, tournamentError(err)
+	}
+
+	return toTournament(tournament), nil
+}
+
+func tournamentError(err error) error {
+	switch {
+	case errors.Is(err, usecase.ErrTournamentNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, usecase.ErrTournamentStarted):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func toTournament(tournament *usecase.Tournament) *quiz.Tournament {
+	res := &quiz.Tournament{
+		Id:        tournament.ID,
+		Name:      tournament.Name,
+		Format:    tournament.Format,
+		State:     toState(tournament.State),
+		Players:   tournament.Players,
+		Winner:    tournament.Winner,
+		CreatedAt: timestamppb.New(tournament.CreatedAt),
+	}
+
+	for i, matches := range tournament.Rounds {
+		round := &quiz.TournamentRound{Round: int32(i + 1)}
+		for _, match := range matches {
+			round.Matches = append(round.Matches, &quiz.TournamentMatch{
+				Id:      match.ID,
+				Round:   int32(match.Round),
+				Players: match.Players,
+				Room:    match.Room,
+				State:   toState(match.State),
+				Winner:  match.Winner,
+				Scores:  usecase.ProtoScores(match.Scores),
+				Bye:     match.Bye,
+			})
+		}
+		res.Rounds = append(res.Rounds, round)
+	}
+
+	for _, standing := range tournament.Standings() {
+		res.Standings = append(res.Standings, &quiz.Standing{
+			Player: standing.Player,
+			Win:    int32(standing.Win),
+			Draw:   int32(standing.Draw),
+			Loss:   int32(standing.Loss),
+			Point:  int32(standing.Point),
+		})
+	}
+
+	return res
+}
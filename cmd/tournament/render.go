@@ -0,0 +1,120 @@
+package tournament
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/elangreza14/grpc-quiz/internal/usecase"
+	quiz "github.com/elangreza14/grpc-quiz/proto"
+)
+
+const (
+	bold  = "\033[1m"
+	green = "\033[32m"
+	reset = "\033[0m"
+)
+
+// render print the bracket, the round which is not created yet is printed as placeholder
+func render(w io.Writer, tournament *quiz.Tournament) {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "%s%s%s  %s (%s) %s\n", bold, strings.ToUpper(tournament.Name), reset, tournament.Id, tournament.Format, tournament.State)
+
+	seeds := []string{}
+	for i, player := range tournament.Players {
+		seeds = append(seeds, fmt.Sprintf("%d. %s", i+1, player))
+	}
+	fmt.Fprintf(b, "seeds: %s\n", strings.Join(seeds, "  "))
+
+	total := totalRound(tournament)
+	for i := 0; i < total; i++ {
+		fmt.Fprintf(b, "\n%s%s%s\n", bold, roundTitle(tournament, i, total), reset)
+
+		if i >= len(tournament.Rounds) {
+			for m := 0; m < len(tournament.Rounds[0].Matches)>>i; m++ {
+				fmt.Fprintf(b, "  %-8s %s\n", "", "? vs ?")
+			}
+			continue
+		}
+
+		for _, match := range tournament.Rounds[i].Matches {
+			fmt.Fprintf(b, "  %-8s %-30s %s\n", match.Id, versus(match), matchStatus(match))
+		}
+	}
+
+	if tournament.Format == usecase.RoundRobin {
+		fmt.Fprintf(b, "\n%sSTANDINGS%s\n", bold, reset)
+		for i, standing := range tournament.Standings {
+			fmt.Fprintf(b, "%3d. %-16s W%d D%d L%d  point %d\n", i+1, standing.Player, standing.Win, standing.Draw, standing.Loss, standing.Point)
+		}
+	}
+
+	if tournament.Winner != "" {
+		fmt.Fprintf(b, "\n%sWINNER: %s%s\n", green, tournament.Winner, reset)
+	}
+
+	_, _ = io.WriteString(w, b.String())
+}
+
+// totalRound of the single elimination is known from the first round
+func totalRound(tournament *quiz.Tournament) int {
+	if tournament.Format != usecase.SingleElimination || len(tournament.Rounds) == 0 {
+		return len(tournament.Rounds)
+	}
+
+	total := 1
+	for matches := len(tournament.Rounds[0].Matches); matches > 1; matches /= 2 {
+		total++
+	}
+
+	return total
+}
+
+func roundTitle(tournament *quiz.Tournament, round, total int) string {
+	if tournament.Format == usecase.SingleElimination {
+		switch total - round {
+		case 1:
+			return "FINAL"
+		case 2:
+			return "SEMI-FINAL"
+		}
+	}
+
+	return fmt.Sprintf("ROUND %d", round+1)
+}
+
+func versus(match *quiz.TournamentMatch) string {
+	if match.Bye {
+		return fmt.Sprintf("%s (bye)", match.Players[0])
+	}
+
+	points := map[string]int32{}
+	for _, score := range match.Scores {
+		points[score.Player] = score.Point
+	}
+
+	players := []string{}
+	for _, player := range match.Players {
+		if match.State == quiz.GameState_DONE {
+			player = fmt.Sprintf("%s %d", player, points[player])
+		}
+		players = append(players, player)
+	}
+
+	return strings.Join(players, " vs ")
+}
+
+func matchStatus(match *quiz.TournamentMatch) string {
+	switch {
+	case match.Bye:
+		return ""
+	case match.State == quiz.GameState_DONE && match.Winner == "":
+		return "draw"
+	case match.State == quiz.GameState_DONE:
+		return fmt.Sprintf("%swinner %s%s", green, match.Winner, reset)
+	case match.Room != "":
+		return fmt.Sprintf("playing in %s", match.Room)
+	default:
+		return "waiting"
+	}
+}
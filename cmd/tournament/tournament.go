@@ -0,0 +1,231 @@
+// Package tournament is the cli for creating, following and playing the tournament
+package tournament
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	client "github.com/elangreza14/grpc-quiz/cmd/client"
+	"github.com/elangreza14/grpc-quiz/internal/usecase"
+	quiz "github.com/elangreza14/grpc-quiz/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	// Create is the action for creating the tournament
+	Create = "create"
+	// List is the action for listing the tournaments
+	List = "list"
+	// Start is the action for starting the tournament
+	Start = "start"
+	// Bracket is the action for rendering the bracket
+	Bracket = "bracket"
+	// Play is the action for playing every match of the player
+	Play = "play"
+
+	// pollInterval is how often the bracket is refreshed
+	pollInterval = 2 * time.Second
+)
+
+// Command is the tournament command of the cli
+type Command struct {
+	Action  string
+	ID      string
+	Name    string
+	Format  string
+	Players []string
+	Player  string
+	// Watch render the bracket again until the tournament is done
+	Watch bool
+
+	client quiz.QuizClient
+	out    io.Writer
+}
+
+// NewCommand is ...
+func NewCommand(action string) *Command {
+	return &Command{
+		Action: action,
+		out:    os.Stdout,
+	}
+}
+
+// Start is ...
+func (c *Command) Start(ctx context.Context) error {
+	conn, err := grpc.DialContext(ctx, ":50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	c.client = quiz.NewQuizClient(conn)
+
+	switch c.Action {
+	case Create:
+		tournament, err := c.client.CreateTournament(ctx, &quiz.CreateTournamentRequest{
+			Name:    c.Name,
+			Format:  c.Format,
+			Players: c.Players,
+		})
+		if err != nil {
+			return err
+		}
+
+		render(c.out, tournament)
+	case List:
+		res, err := c.client.ListTournaments(ctx, &quiz.ListTournamentsRequest{})
+		if err != nil {
+			return err
+		}
+
+		for _, tournament := range res.Tournaments {
+			fmt.Fprintf(c.out, "%s  %s (%s) %s, %d players\n", tournament.Id, tournament.Name, tournament.Format, tournament.State, len(tournament.Players))
+		}
+	case Start:
+		tournament, err := c.client.StartTournament(ctx, &quiz.TournamentRequest{Tournament: c.ID})
+		if err != nil {
+			return err
+		}
+
+		render(c.out, tournament)
+	case Bracket:
+		return c.bracket(ctx)
+	case Play:
+		return c.play(ctx)
+	default:
+		return fmt.Errorf("tournament action %s not found", c.Action)
+	}
+
+	return nil
+}
+
+func (c *Command) bracket(ctx context.Context) error {
+	for {
+		tournament, err := c.client.GetBracket(ctx, &quiz.TournamentRequest{Tournament: c.ID})
+		if err != nil {
+			return err
+		}
+
+		if c.Watch {
+			fmt.Fprint(c.out, "\033[H\033[2J")
+		}
+		render(c.out, tournament)
+
+		if !c.Watch || tournament.State == quiz.GameState_DONE {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// play join the room of every match of the player until the player is out or the tournament is done
+func (c *Command) play(ctx context.Context) error {
+	// one reader for the terminal, the answer is passed to the client of each match
+	lines := make(chan string)
+	go func() {
+		terminal := usecase.NewTerminal(os.Stdin)
+		for {
+			line, ok := terminal.ValText()
+			if !ok {
+				return
+			}
+			lines <- line
+		}
+	}()
+
+	played := map[string]bool{}
+	waiting := false
+	for {
+		tournament, err := c.client.GetBracket(ctx, &quiz.TournamentRequest{Tournament: c.ID})
+		if err != nil {
+			return err
+		}
+
+		if tournament.State == quiz.GameState_DONE {
+			render(c.out, tournament)
+			return nil
+		}
+
+		if eliminated(tournament, c.Player) {
+			fmt.Fprintln(c.out, "you are eliminated from the tournament")
+			render(c.out, tournament)
+			return nil
+		}
+
+		match := nextMatch(tournament, c.Player, played)
+		if match == nil {
+			if !waiting {
+				fmt.Fprintln(c.out, "waiting for your next match...")
+				waiting = true
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(pollInterval):
+			}
+			continue
+		}
+
+		played[match.Id] = true
+		waiting = false
+		fmt.Fprintf(c.out, "match %s: %s\n", match.Id, versus(match))
+
+		player := client.NewClient(c.Player, match.Room, "")
+		player.Input = lines
+		if err := player.Start(ctx); err != nil {
+			fmt.Fprintln(c.out, err.Error())
+		}
+	}
+}
+
+// nextMatch return the match of the player which room is ready
+func nextMatch(tournament *quiz.Tournament, player string, played map[string]bool) *quiz.TournamentMatch {
+	for _, round := range tournament.Rounds {
+		for _, match := range round.Matches {
+			if played[match.Id] || match.State != quiz.GameState_ON_PROGRESS || match.Room == "" {
+				continue
+			}
+
+			for _, p := range match.Players {
+				if p == player {
+					return match
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// eliminated is only for the single elimination, the player who lose a match is out
+func eliminated(tournament *quiz.Tournament, player string) bool {
+	if tournament.Format != usecase.SingleElimination {
+		return false
+	}
+
+	for _, round := range tournament.Rounds {
+		for _, match := range round.Matches {
+			if match.State != quiz.GameState_DONE || match.Winner == player {
+				continue
+			}
+
+			for _, p := range match.Players {
+				if p == player {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,89 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/elangreza14/grpc-quiz/internal/usecase"
+)
+
+var (
+	aikenOption = regexp.MustCompile(`^([A-Z])[.)]\s+(.*)$`)
+	aikenAnswer = regexp.MustCompile(`^ANSWER:\s*(.*)$`)
+)
+
+// parseAiken read the question line, the options like "A. text" or "A) text" and the "ANSWER: A" line
+func parseAiken(r io.Reader) ([]entry, []Issue, error) {
+	entries := []entry{}
+	issues := []Issue{}
+
+	var current *entry
+	// invalid is set when the current question is already reported, the rest is skipped until the answer
+	invalid := false
+	report := func(line int, format string, args ...any) {
+		issues = append(issues, Issue{Line: line, Message: fmt.Sprintf(format, args...)})
+		invalid = true
+	}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		if current == nil {
+			current = &entry{line: line, question: usecase.Question{Question: text}}
+			invalid = false
+			continue
+		}
+
+		if match := aikenAnswer.FindStringSubmatch(text); match != nil {
+			if !invalid && len(current.question.Options) == 0 {
+				report(current.line, "the question has no option")
+			}
+
+			if !invalid {
+				current.question.Answer = strings.TrimSpace(match[1])
+				entries = append(entries, *current)
+			}
+			current = nil
+			continue
+		}
+
+		if invalid {
+			continue
+		}
+
+		if match := aikenOption.FindStringSubmatch(text); match != nil {
+			if want := key(len(current.question.Options)); match[1] != want {
+				report(line, "option %s must be %s, the options must be in order", match[1], want)
+				continue
+			}
+
+			current.question.Options = append(current.question.Options, match[2])
+			continue
+		}
+
+		// the question can be written in multiple line before the options
+		if len(current.question.Options) == 0 {
+			current.question.Question += " " + text
+			continue
+		}
+
+		report(line, "unexpected line %q after the options", text)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	if current != nil && !invalid {
+		issues = append(issues, Issue{Line: current.line, Message: "the question has no ANSWER line"})
+	}
+
+	return entries, issues, nil
+}
@@ -0,0 +1,158 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/elangreza14/grpc-quiz/internal/usecase"
+)
+
+// csvSeparator separate the hints and the tags in a column
+const csvSeparator = "|"
+
+// parseCSV read the file with the header. question and answer is required,
+// the options is in the column A, B, C and so on. The answer is the key, the text of the option or true/false.
+// id, explanation, difficulty, category, hints, tags, time_limit and weight is optional, hints and tags is separated by |
+func parseCSV(r io.Reader) ([]entry, []Issue, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid csv header: %w", err)
+	}
+
+	issues := []Issue{}
+	columns := map[string]int{}
+	options := []int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch {
+		case len(name) == 1 && name[0] >= 'a' && name[0] <= 'z':
+			if want := strings.ToLower(key(len(options))); name != want {
+				issues = append(issues, Issue{Line: 1, Message: fmt.Sprintf("option column %s must be %s, the column is ignored", name, want)})
+				continue
+			}
+			options = append(options, i)
+		case name == "id", name == "question", name == "answer", name == "explanation",
+			name == "difficulty", name == "category", name == "hints", name == "tags",
+			name == "time_limit", name == "weight":
+			columns[name] = i
+		default:
+			issues = append(issues, Issue{Line: 1, Message: fmt.Sprintf("column %q is not supported, the column is ignored", name)})
+		}
+	}
+
+	if _, ok := columns["question"]; !ok {
+		return nil, nil, errors.New("csv header must have question and answer column")
+	}
+	if _, ok := columns["answer"]; !ok {
+		return nil, nil, errors.New("csv header must have question and answer column")
+	}
+
+	entries := []entry{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+
+			return strings.TrimSpace(record[i])
+		}
+
+		question := usecase.Question{
+			ID:          field("id"),
+			Question:    field("question"),
+			Explanation: field("explanation"),
+			Difficulty:  field("difficulty"),
+			Category:    field("category"),
+			Hints:       split(field("hints")),
+			Tags:        split(field("tags")),
+		}
+
+		if question.TimeLimit, err = number(field("time_limit")); err != nil {
+			issues = append(issues, Issue{Line: line, Message: fmt.Sprintf("time_limit %q must be the seconds, the question is skipped", field("time_limit"))})
+			continue
+		}
+		if question.Weight, err = number(field("weight")); err != nil {
+			issues = append(issues, Issue{Line: line, Message: fmt.Sprintf("weight %q must be a number, the question is skipped", field("weight"))})
+			continue
+		}
+
+		for _, i := range options {
+			if i < len(record) && strings.TrimSpace(record[i]) != "" {
+				question.Options = append(question.Options, strings.TrimSpace(record[i]))
+			}
+		}
+
+		question.Answer = csvAnswer(field("answer"), question.Options)
+		entries = append(entries, entry{line: line, question: question})
+	}
+
+	return entries, issues, nil
+}
+
+// csvAnswer return the key of the answer, the answer can be the key or the text of the option
+func csvAnswer(answer string, options []string) string {
+	if len(options) == 0 {
+		if key, ok := trueFalse(answer); ok {
+			return key
+		}
+
+		return answer
+	}
+
+	// the key is preferred, e.g. the answer B is the key even when an option is B
+	for i := range options {
+		if strings.EqualFold(key(i), answer) {
+			return key(i)
+		}
+	}
+
+	for i, option := range options {
+		if strings.EqualFold(option, answer) {
+			return key(i)
+		}
+	}
+
+	return answer
+}
+
+func split(s string) []string {
+	values := []string{}
+	for _, value := range strings.Split(s, csvSeparator) {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	return values
+}
+
+// number is zero when the field is empty
+func number(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	return strconv.Atoi(s)
+}
@@ -0,0 +1,210 @@
+package importer
+
+import (
+	"bufio"
+	"errors"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/elangreza14/grpc-quiz/internal/usecase"
+)
+
+// giftBlank replace the answer of the missing word question
+const giftBlank = "_____"
+
+// giftEscapes replace the escaped special character with the private rune while parsing
+var giftEscapes = strings.NewReplacer(
+	`\~`, "\ue000",
+	`\=`, "\ue001",
+	`\#`, "\ue002",
+	`\{`, "\ue003",
+	`\}`, "\ue004",
+	`\:`, "\ue005",
+	`\n`, "\n",
+)
+
+var giftUnescapes = strings.NewReplacer(
+	"\ue000", "~",
+	"\ue001", "=",
+	"\ue002", "#",
+	"\ue003", "{",
+	"\ue004", "}",
+	"\ue005", ":",
+)
+
+// parseGIFT support the true-false, the multiple choice and the missing word question.
+// The category is the last part of $CATEGORY, the general feedback or the feedback of the correct answer is the explanation
+func parseGIFT(r io.Reader) ([]entry, []Issue, error) {
+	entries := []entry{}
+	issues := []Issue{}
+
+	category := ""
+	start := 0
+	block := []string{}
+	flush := func() {
+		if len(block) > 0 {
+			question, err := parseGIFTQuestion(strings.Join(block, "\n"))
+			if err != nil {
+				issues = append(issues, Issue{Line: start, Message: err.Error()})
+			} else {
+				question.Category = category
+				entries = append(entries, entry{line: start, question: question})
+			}
+		}
+		block = nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(text, "//"):
+		case text == "":
+			flush()
+		case strings.HasPrefix(text, "$CATEGORY:"):
+			flush()
+			path := strings.Split(strings.TrimSpace(strings.TrimPrefix(text, "$CATEGORY:")), "/")
+			category = slug(path[len(path)-1])
+		default:
+			if len(block) == 0 {
+				start = line
+			}
+			block = append(block, text)
+		}
+	}
+	flush()
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return entries, issues, nil
+}
+
+func parseGIFTQuestion(text string) (usecase.Question, error) {
+	text = giftEscapes.Replace(text)
+
+	// the title is not imported
+	if strings.HasPrefix(text, "::") {
+		end := strings.Index(text[2:], "::")
+		if end < 0 {
+			return usecase.Question{}, errors.New("the title is not closed with ::")
+		}
+		text = strings.TrimSpace(text[end+4:])
+	}
+
+	isHTML := false
+	if end := strings.Index(text, "]"); strings.HasPrefix(text, "[") && end > 0 {
+		switch text[1:end] {
+		case "html":
+			isHTML = true
+			text = text[end+1:]
+		case "moodle", "plain", "markdown":
+			text = text[end+1:]
+		}
+	}
+
+	open := strings.Index(text, "{")
+	closed := strings.LastIndex(text, "}")
+	if open < 0 || closed < open {
+		return usecase.Question{}, errors.New("description without answer is not supported")
+	}
+
+	before, body, after := strings.TrimSpace(text[:open]), strings.TrimSpace(text[open+1:closed]), strings.TrimSpace(text[closed+1:])
+	question := usecase.Question{Question: before}
+	if after != "" {
+		question.Question = strings.TrimSpace(before + " " + giftBlank + " " + after)
+	}
+
+	// the general feedback is after ####
+	if i := strings.Index(body, "####"); i >= 0 {
+		question.Explanation = strings.TrimSpace(body[i+4:])
+		body = strings.TrimSpace(body[:i])
+	}
+
+	if err := parseGIFTAnswer(body, &question); err != nil {
+		return usecase.Question{}, err
+	}
+
+	question.Question = unescapeGIFT(question.Question, isHTML)
+	question.Explanation = unescapeGIFT(question.Explanation, isHTML)
+	for i, option := range question.Options {
+		question.Options[i] = unescapeGIFT(option, isHTML)
+	}
+
+	return question, nil
+}
+
+func parseGIFTAnswer(body string, question *usecase.Question) error {
+	switch {
+	case body == "":
+		return errors.New("essay question is not supported")
+	case strings.HasPrefix(body, "#"):
+		return errors.New("numerical question is not supported")
+	}
+
+	// true-false question, the feedback is not imported
+	answer, _, _ := strings.Cut(body, "#")
+	if key, ok := trueFalse(answer); ok && !strings.ContainsAny(answer, "=~") {
+		question.Answer = key
+		return nil
+	}
+
+	if strings.Contains(body, "->") {
+		return errors.New("matching question is not supported")
+	}
+
+	if strings.Contains(body, "%") {
+		return errors.New("weighted answer is not supported")
+	}
+
+	if !strings.Contains(body, "~") {
+		return errors.New("short answer question is not supported")
+	}
+
+	// every answer is started with = or ~, the feedback of the answer is after #
+	correct := 0
+	for i := 0; i < len(body); {
+		mark := body[i]
+		if mark != '=' && mark != '~' {
+			return errors.New("the answer must be started with = or ~")
+		}
+
+		end := strings.IndexAny(body[i+1:], "=~")
+		if end < 0 {
+			end = len(body)
+		} else {
+			end += i + 1
+		}
+
+		option, feedback, _ := strings.Cut(body[i+1:end], "#")
+		if mark == '=' {
+			correct++
+			question.Answer = key(len(question.Options))
+			if question.Explanation == "" {
+				question.Explanation = strings.TrimSpace(feedback)
+			}
+		}
+		question.Options = append(question.Options, strings.TrimSpace(option))
+		i = end
+	}
+
+	switch {
+	case correct == 0:
+		return errors.New("multiple choice without correct answer")
+	case correct > 1:
+		return errors.New("multiple choice with more than one correct answer is not supported")
+	}
+
+	return nil
+}
+
+func unescapeGIFT(s string, isHTML bool) string {
+	s = giftUnescapes.Replace(s)
+	if isHTML {
+		s = html.UnescapeString(s)
+	}
+
+	return s
+}
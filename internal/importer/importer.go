@@ -0,0 +1,166 @@
+// Package importer convert the question from the other format to the question bank
+package importer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/elangreza14/grpc-quiz/internal/usecase"
+)
+
+const (
+	// OpenTDB is the json dump of Open Trivia DB
+	OpenTDB = "opentdb"
+	// GIFT is the Moodle GIFT format
+	GIFT = "gift"
+	// Aiken is the Moodle Aiken format
+	Aiken = "aiken"
+	// CSV is question, answer and the option columns, see parseCSV
+	CSV = "csv"
+)
+
+type (
+	// Issue is the question which is skipped or partially imported
+	Issue struct {
+		// Line is the line of the question, it is the number of the question in the json dump
+		Line    int
+		Message string
+	}
+
+	// entry is the parsed question with its line
+	entry struct {
+		line     int
+		question usecase.Question
+	}
+
+	parser func(r io.Reader) ([]entry, []Issue, error)
+)
+
+var parsers = map[string]parser{
+	OpenTDB: parseOpenTDB,
+	GIFT:    parseGIFT,
+	Aiken:   parseAiken,
+	CSV:     parseCSV,
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("line %d: %s", i.Line, i.Message)
+}
+
+// Formats is ...
+func Formats() []string {
+	return []string{OpenTDB, GIFT, Aiken, CSV}
+}
+
+// DetectFormat return the format from the extension of the file
+func DetectFormat(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return OpenTDB, nil
+	case ".gift":
+		return GIFT, nil
+	case ".aiken":
+		return Aiken, nil
+	case ".csv":
+		return CSV, nil
+	default:
+		return "", fmt.Errorf("can't detect the format of %s, use one of %s", path, strings.Join(Formats(), ", "))
+	}
+}
+
+// Import read the questions, the unsupported or invalid question is skipped and reported as issue
+func Import(format string, r io.Reader) ([]usecase.Question, []Issue, error) {
+	parse, ok := parsers[format]
+	if !ok {
+		return nil, nil, fmt.Errorf("format %s not found, use one of %s", format, strings.Join(Formats(), ", "))
+	}
+
+	entries, issues, err := parse(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	questions := []usecase.Question{}
+	for _, entry := range entries {
+		if err := entry.question.Validate(); err != nil {
+			issues = append(issues, Issue{Line: entry.line, Message: err.Error()})
+			continue
+		}
+
+		questions = append(questions, entry.question)
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Line < issues[j].Line
+	})
+
+	return questions, issues, nil
+}
+
+// ImportFile is Import from the file, empty format is detected from the extension
+func ImportFile(path, format string) ([]usecase.Question, []Issue, error) {
+	if format == "" {
+		var err error
+		if format, err = DetectFormat(path); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	return Import(format, f)
+}
+
+// NewBank create the bank with every category of the questions
+func NewBank(name string, questions []usecase.Question) *usecase.QuestionBank {
+	categories := []string{}
+	seen := map[string]bool{}
+	for _, question := range questions {
+		if question.Category != "" && !seen[question.Category] {
+			seen[question.Category] = true
+			categories = append(categories, question.Category)
+		}
+	}
+	sort.Strings(categories)
+
+	return &usecase.QuestionBank{
+		Name:       name,
+		Categories: categories,
+		Questions:  questions,
+	}
+}
+
+// slug is the category usable in the draw rule, e.g. "Entertainment: Video Games" is entertainment-video-games
+func slug(s string) string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	return strings.Join(words, "-")
+}
+
+// trueFalse return Y or N from the answer of the true or false question
+func trueFalse(answer string) (string, bool) {
+	switch strings.ToUpper(strings.TrimSpace(answer)) {
+	case "Y", "T", "TRUE", "YES":
+		return "Y", true
+	case "N", "F", "FALSE", "NO":
+		return "N", true
+	default:
+		return "", false
+	}
+}
+
+// key is the key of the option at index, e.g. B for 1
+func key(index int) string {
+	return string(rune('A' + index))
+}
@@ -0,0 +1,201 @@
+package importer
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/elangreza14/grpc-quiz/internal/usecase"
+)
+
+func TestImportFile(t *testing.T) {
+	tests := []struct {
+		file      string
+		questions []usecase.Question
+		issues    []Issue
+	}{
+		{
+			file: "opentdb.json",
+			questions: []usecase.Question{
+				{
+					Question:   `What is the chemical symbol for "sodium"?`,
+					Options:    []string{"Na", "S", "Sd", "So"},
+					Answer:     "A",
+					Difficulty: "easy",
+					Category:   "science-nature",
+				},
+				{
+					Question:   `"Minecraft" was released in 2011.`,
+					Answer:     "Y",
+					Difficulty: "medium",
+					Category:   "entertainment-video-games",
+				},
+			},
+			issues: []Issue{
+				{Line: 3, Message: `question type "matching" is not supported`},
+				{Line: 4, Message: "difficulty extreme not found, use easy, medium or hard"},
+			},
+		},
+		{
+			file: "questions.gift",
+			questions: []usecase.Question{
+				{
+					Question:    "Which planet is known as the red planet?",
+					Options:     []string{"Venus", "Mars", "Jupiter"},
+					Answer:      "B",
+					Explanation: "iron oxide makes it red",
+					Category:    "astronomy",
+				},
+				{
+					Question: "The sun is a star.",
+					Answer:   "Y",
+					Category: "astronomy",
+				},
+				{
+					Question: "Mahatma Gandhi's birthday is an _____ holiday.",
+					Options:  []string{"Chinese", "Indian", "Japanese"},
+					Answer:   "B",
+					Category: "astronomy",
+				},
+				{
+					Question:    "What is 2 + 2 = ?",
+					Options:     []string{"3", "4", "5"},
+					Answer:      "B",
+					Explanation: "2 and 2 make 4",
+					Category:    "math",
+				},
+				{
+					Question: "Is 5 > 3?",
+					Answer:   "Y",
+					Category: "math",
+				},
+			},
+			issues: []Issue{
+				{Line: 20, Message: "numerical question is not supported"},
+				{Line: 22, Message: "essay question is not supported"},
+				{Line: 24, Message: "matching question is not supported"},
+				{Line: 26, Message: "weighted answer is not supported"},
+				{Line: 28, Message: "short answer question is not supported"},
+				{Line: 30, Message: "description without answer is not supported"},
+			},
+		},
+		{
+			file: "questions.aiken",
+			questions: []usecase.Question{
+				{
+					Question: "What is the capital of France?",
+					Options:  []string{"Berlin", "Paris", "Madrid"},
+					Answer:   "B",
+				},
+				{
+					Question: "Which gas do plants absorb from the air?",
+					Options:  []string{"Oxygen", "Carbon dioxide", "Nitrogen"},
+					Answer:   "B",
+				},
+			},
+			issues: []Issue{
+				{Line: 16, Message: "option C must be B, the options must be in order"},
+				{Line: 19, Message: `answer "D" must be one of A, B`},
+				{Line: 24, Message: "the question has no option"},
+				{Line: 27, Message: "the question has no ANSWER line"},
+			},
+		},
+		{
+			file: "questions.csv",
+			questions: []usecase.Question{
+				{
+					ID:          "csv-1",
+					Question:    "What is the capital of Italy?",
+					Options:     []string{"Rome", "Milan", "Naples", "Turin"},
+					Answer:      "A",
+					Explanation: "Rome is the capital since 1871",
+					Hints:       []string{"it is not milan"},
+					Difficulty:  "easy",
+					Category:    "geography",
+					Tags:        []string{"capital", "europe"},
+				},
+				{
+					ID:         "csv-2",
+					Question:   "The earth is flat",
+					Answer:     "N",
+					Difficulty: "medium",
+					Category:   "science",
+				},
+				{
+					ID:         "csv-3",
+					Question:   "Which is the smallest prime?",
+					Options:    []string{"1", "2", "3"},
+					Answer:     "B",
+					Difficulty: "hard",
+					Category:   "math",
+					Tags:       []string{"prime"},
+					TimeLimit:  30,
+					Weight:     3,
+				},
+			},
+			issues: []Issue{
+				{Line: 1, Message: `column "author" is not supported, the column is ignored`},
+				{Line: 5, Message: `answer "Green" must be one of A, B`},
+				{Line: 6, Message: `weight "heavy" must be a number, the question is skipped`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			questions, issues, err := ImportFile(filepath.Join("testdata", tt.file), "")
+			if err != nil {
+				t.Fatalf("ImportFile() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(questions, tt.questions) {
+				t.Errorf("ImportFile() questions = %+v, want %+v", questions, tt.questions)
+			}
+
+			if !reflect.DeepEqual(issues, tt.issues) {
+				t.Errorf("ImportFile() issues = %v, want %v", issues, tt.issues)
+			}
+		})
+	}
+}
+
+func TestImportedBankIsPlayable(t *testing.T) {
+	for _, format := range Formats() {
+		matches, _ := filepath.Glob(filepath.Join("testdata", "*"))
+		for _, file := range matches {
+			if detected, _ := DetectFormat(file); detected != format {
+				continue
+			}
+
+			questions, _, err := ImportFile(file, format)
+			if err != nil {
+				t.Fatalf("ImportFile(%s) error = %v", file, err)
+			}
+
+			if _, err := NewBank(format, questions).Payloads(); err != nil {
+				t.Errorf("bank of %s is not playable: %v", file, err)
+			}
+		}
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		path    string
+		format  string
+		wantErr bool
+	}{
+		{path: "dump.json", format: OpenTDB},
+		{path: "quiz.GIFT", format: GIFT},
+		{path: "quiz.aiken", format: Aiken},
+		{path: "quiz.csv", format: CSV},
+		{path: "quiz.txt", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		format, err := DetectFormat(tt.path)
+		if (err != nil) != tt.wantErr || format != tt.format {
+			t.Errorf("DetectFormat(%s) = %s, %v, want %s", tt.path, format, err, tt.format)
+		}
+	}
+}
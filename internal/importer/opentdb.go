@@ -0,0 +1,93 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"sort"
+
+	"github.com/elangreza14/grpc-quiz/internal/usecase"
+)
+
+type (
+	// openTDBDump is the response of the api, the dump can also be the results only
+	openTDBDump struct {
+		ResponseCode int               `json:"response_code"`
+		Results      []openTDBQuestion `json:"results"`
+	}
+
+	openTDBQuestion struct {
+		Type             string   `json:"type"`
+		Difficulty       string   `json:"difficulty"`
+		Category         string   `json:"category"`
+		Question         string   `json:"question"`
+		CorrectAnswer    string   `json:"correct_answer"`
+		IncorrectAnswers []string `json:"incorrect_answers"`
+	}
+)
+
+// parseOpenTDB decode the html entity of the text, the options of the multiple question is sorted
+// so the correct answer is not always in the same position
+func parseOpenTDB(r io.Reader) ([]entry, []Issue, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	results := []openTDBQuestion{}
+	if data = bytes.TrimSpace(data); len(data) > 0 && data[0] == '[' {
+		err = json.Unmarshal(data, &results)
+	} else {
+		dump := openTDBDump{}
+		err = json.Unmarshal(data, &dump)
+		if err == nil && dump.ResponseCode != 0 {
+			err = fmt.Errorf("the dump has response code %d", dump.ResponseCode)
+		}
+		results = dump.Results
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid open trivia db dump: %w", err)
+	}
+
+	entries := []entry{}
+	issues := []Issue{}
+	for i, result := range results {
+		question := usecase.Question{
+			Question:   html.UnescapeString(result.Question),
+			Difficulty: result.Difficulty,
+			Category:   slug(html.UnescapeString(result.Category)),
+		}
+
+		switch result.Type {
+		case "boolean":
+			answer, ok := trueFalse(result.CorrectAnswer)
+			if !ok {
+				issues = append(issues, Issue{Line: i + 1, Message: fmt.Sprintf("boolean answer %q must be True or False", result.CorrectAnswer)})
+				continue
+			}
+			question.Answer = answer
+		case "multiple":
+			correct := html.UnescapeString(result.CorrectAnswer)
+			question.Options = []string{correct}
+			for _, option := range result.IncorrectAnswers {
+				question.Options = append(question.Options, html.UnescapeString(option))
+			}
+			sort.Strings(question.Options)
+
+			for j, option := range question.Options {
+				if option == correct {
+					question.Answer = key(j)
+				}
+			}
+		default:
+			issues = append(issues, Issue{Line: i + 1, Message: fmt.Sprintf("question type %q is not supported", result.Type)})
+			continue
+		}
+
+		entries = append(entries, entry{line: i + 1, question: question})
+	}
+
+	return entries, issues, nil
+}
@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strings"
+	"sync"
+)
+
+const (
+	// Easy is ...
+	Easy = 1
+	// Medium is the difficulty of the question without difficulty
+	Medium = 2
+	// Hard is ...
+	Hard = 3
+
+	// DefaultTargetAccuracy is the success rate aimed by the adaptive difficulty
+	DefaultTargetAccuracy = 0.7
+
+	// adaptiveWindow is total recent answer used for the accuracy,
+	// the difficulty is changed when the accuracy is out of the margin
+	adaptiveWindow = 5
+	adaptiveMargin = 0.15
+)
+
+type (
+	// AdaptiveConfig pick the difficulty of the next question from the recent accuracy.
+	// The same seed with the same answers produce the same sequence of question
+	AdaptiveConfig struct {
+		// Target is the success rate, zero mean the default target
+		Target float64
+		Seed   int64
+	}
+
+	// adaptiveSelector pick the question from the pool, the question is never repeated
+	adaptiveSelector struct {
+		target float64
+		rand   *rand.Rand
+		pool   []QuestionPayload
+		level  int
+		recent []bool
+	}
+
+	// adaptiveMode is classic rule set with the question picked by the accuracy of the room
+	adaptiveMode struct {
+		mu         sync.Mutex
+		selector   *adaptiveSelector
+		totalRound int
+		picked     []QuestionPayload
+	}
+)
+
+// ParseDifficulty is ...
+func ParseDifficulty(s string) (int, error) {
+	switch strings.ToLower(s) {
+	case "easy":
+		return Easy, nil
+	case "medium", "":
+		return Medium, nil
+	case "hard":
+		return Hard, nil
+	default:
+		return 0, fmt.Errorf("difficulty %s not found, use easy, medium or hard", s)
+	}
+}
+
+// DifficultyName is ...
+func DifficultyName(difficulty int) string {
+	switch difficulty {
+	case Easy:
+		return "easy"
+	case Hard:
+		return "hard"
+	default:
+		return "medium"
+	}
+}
+
+func newAdaptiveSelector(questions []QuestionPayload, cfg AdaptiveConfig, seed int64) *adaptiveSelector {
+	target := cfg.Target
+	if target <= 0 || target >= 1 {
+		target = DefaultTargetAccuracy
+	}
+
+	return &adaptiveSelector{
+		target: target,
+		rand:   rand.New(rand.NewSource(seed)),
+		pool:   append([]QuestionPayload{}, questions...),
+		level:  Medium,
+	}
+}
+
+// next pick randomly from the question nearest to the current level
+func (s *adaptiveSelector) next() (QuestionPayload, bool) {
+	if len(s.pool) == 0 {
+		return QuestionPayload{}, false
+	}
+
+	candidates := []int{}
+	nearest := -1
+	for i, question := range s.pool {
+		distance := question.level() - s.level
+		if distance < 0 {
+			distance = -distance
+		}
+
+		switch {
+		case nearest == -1 || distance < nearest:
+			nearest = distance
+			candidates = []int{i}
+		case distance == nearest:
+			candidates = append(candidates, i)
+		}
+	}
+
+	i := candidates[s.rand.Intn(len(candidates))]
+	question := s.pool[i]
+	s.pool = append(s.pool[:i], s.pool[i+1:]...)
+
+	return question, true
+}
+
+// record the answer, the level is changed when the recent accuracy is out of the target
+func (s *adaptiveSelector) record(correct ...bool) {
+	s.recent = append(s.recent, correct...)
+	if len(s.recent) > adaptiveWindow {
+		s.recent = s.recent[len(s.recent)-adaptiveWindow:]
+	}
+
+	if len(s.recent) == 0 {
+		return
+	}
+
+	total := 0
+	for _, ok := range s.recent {
+		if ok {
+			total++
+		}
+	}
+	accuracy := float64(total) / float64(len(s.recent))
+
+	switch {
+	case accuracy > s.target+adaptiveMargin && s.level < Hard:
+		s.level++
+		s.recent = nil
+	case accuracy < s.target-adaptiveMargin && s.level > Easy:
+		s.level--
+		s.recent = nil
+	}
+}
+
+// playerSeed is different for each player, so the sequence of the player is reproducible
+func playerSeed(seed int64, name string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+
+	return seed ^ int64(h.Sum64())
+}
+
+func newAdaptiveMode(questions []QuestionPayload, totalRound int, cfg AdaptiveConfig) *adaptiveMode {
+	if totalRound <= 0 || totalRound > len(questions) {
+		totalRound = len(questions)
+	}
+
+	return &adaptiveMode{
+		selector:   newAdaptiveSelector(questions, cfg, cfg.Seed),
+		totalRound: totalRound,
+	}
+}
+
+func (m *adaptiveMode) Name() string { return ClassicMode }
+
+func (m *adaptiveMode) Start([]string) {}
+
+func (m *adaptiveMode) Next(round int) (QuestionPayload, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if round >= m.totalRound {
+		return QuestionPayload{}, false
+	}
+
+	for len(m.picked) <= round {
+		question, ok := m.selector.next()
+		if !ok {
+			return QuestionPayload{}, false
+		}
+		m.picked = append(m.picked, question)
+	}
+
+	return m.picked[round], true
+}
+
+func (m *adaptiveMode) CanAnswer(string) error { return nil }
+
+// EndRound record the accuracy of the room, the skipped question is not counted
+func (m *adaptiveMode) EndRound(summary RoundSummary) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	answers := []bool{}
+	for _, player := range summary.Players {
+		if summary.Skipped[player] {
+			continue
+		}
+
+		answer, ok := summary.Answers[player]
+		answers = append(answers, ok && answer == summary.Answer)
+	}
+	m.selector.record(answers...)
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// adaptivePool is 4 questions of each difficulty, the question is named by the difficulty
+func adaptivePool() []QuestionPayload {
+	questions := []QuestionPayload{}
+	for _, difficulty := range []int{Easy, Medium, Hard} {
+		for i := 0; i < 4; i++ {
+			questions = append(questions, QuestionPayload{
+				question:   fmt.Sprintf("%s-%d", DifficultyName(difficulty), i),
+				answer:     "Y",
+				difficulty: difficulty,
+			})
+		}
+	}
+
+	return questions
+}
+
+// adaptiveSequence pick every question, the answers of the room is recorded after the pick
+func adaptiveSequence(seed int64, answers [][]bool) ([]string, []int) {
+	selector := newAdaptiveSelector(adaptivePool(), AdaptiveConfig{}, seed)
+
+	picked := []string{}
+	levels := []int{}
+	for i := 0; ; i++ {
+		question, ok := selector.next()
+		if !ok {
+			return picked, levels
+		}
+		picked = append(picked, question.question)
+		levels = append(levels, question.level())
+
+		if i < len(answers) {
+			selector.record(answers[i]...)
+		}
+	}
+}
+
+func TestAdaptiveSelector(t *testing.T) {
+	tests := []struct {
+		name string
+		// answers is the answer of each player in the round
+		answers [][]bool
+		// wantLevels is the difficulty of the first questions, the nearest level is picked when the level is empty
+		wantLevels []int
+	}{
+		{
+			name:       "the correct answer move to the hard question",
+			answers:    [][]bool{{true, true, true}},
+			wantLevels: []int{Medium, Hard, Hard, Hard, Hard, Medium},
+		},
+		{
+			name:       "the wrong answer move to the easy question",
+			answers:    [][]bool{{true, false, false}},
+			wantLevels: []int{Medium, Easy, Easy, Easy, Easy, Medium},
+		},
+		{
+			name:       "the accuracy is counted in the recent answers",
+			answers:    [][]bool{{true, true, false}, {true, false}, {false, false}},
+			wantLevels: []int{Medium, Medium, Medium, Easy},
+		},
+		{
+			name:       "the accuracy in the margin keep the level",
+			answers:    [][]bool{{true, true, false}, {true, false, true}},
+			wantLevels: []int{Medium, Medium, Medium, Medium, Hard},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			picked, levels := adaptiveSequence(42, tt.answers)
+			if len(picked) != len(adaptivePool()) {
+				t.Fatalf("total picked = %d, want %d", len(picked), len(adaptivePool()))
+			}
+
+			seen := map[string]bool{}
+			for _, question := range picked {
+				if seen[question] {
+					t.Errorf("%s is picked twice", question)
+				}
+				seen[question] = true
+			}
+
+			if got := levels[:len(tt.wantLevels)]; !reflect.DeepEqual(got, tt.wantLevels) {
+				t.Errorf("levels = %v, want %v", got, tt.wantLevels)
+			}
+
+			// the same seed with the same answers produce the same sequence
+			again, _ := adaptiveSequence(42, tt.answers)
+			if !reflect.DeepEqual(again, picked) {
+				t.Errorf("sequence = %v, want %v", again, picked)
+			}
+		})
+	}
+}
+
+// playAdaptive play every round with the same answers, the answer of the player depend on the round
+func playAdaptive(t *testing.T, players []string) []string {
+	t.Helper()
+
+	clock := NewManualClock(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	game, err := NewGamePlay(GameConfig{
+		Questions: adaptivePool(),
+		Adaptive:  &AdaptiveConfig{Seed: 7},
+		Clock:     clock,
+	})
+	if err != nil {
+		t.Fatalf("NewGamePlay() error = %v", err)
+	}
+	for _, player := range players {
+		game.AddPlayer(player)
+	}
+	game.Start()
+	h := &harness{t: t, clock: clock, game: game}
+
+	picked := []string{}
+	for {
+		state := h.next()
+		if state.State == Done {
+			return picked
+		}
+
+		question, ok := state.payload.(QuestionEvent)
+		if !ok {
+			continue
+		}
+		picked = append(picked, question.Question)
+
+		for i, player := range players {
+			answer := "Y"
+			if (i+question.Round)%len(players) == 0 {
+				answer = "N"
+			}
+			h.answer(player, answer)
+		}
+	}
+}
+
+func TestGamePlayAdaptiveReproducible(t *testing.T) {
+	// more players than the window of the accuracy, the order of the answer is counted
+	players := []string{"ann", "bob", "cat", "dan", "eve", "fay"}
+	want := playAdaptive(t, players)
+	if len(want) != len(adaptivePool()) {
+		t.Fatalf("total round = %d, want %d", len(want), len(adaptivePool()))
+	}
+
+	for i := 0; i < 10; i++ {
+		if got := playAdaptive(t, players); !reflect.DeepEqual(got, want) {
+			t.Fatalf("run %d = %v, want %v", i+1, got, want)
+		}
+	}
+}
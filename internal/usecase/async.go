@@ -0,0 +1,523 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultAsyncWindow is how long the self-paced quiz is open when the closing time is not set
+const DefaultAsyncWindow = time.Hour
+
+type (
+	// AsyncConfig is ...
+	AsyncConfig struct {
+		// TimePerQuestion is the timer of each question after it is sent to the player
+		TimePerQuestion time.Duration
+		// TimeLimit is the overall time for each player, zero mean until the quiz is closed
+		TimeLimit time.Duration
+		// ClosesAt is the time the quiz is closed and the results are released
+		ClosesAt time.Time
+		// Adaptive pick the question by the accuracy of each player, nil mean the question is asked in order
+		Adaptive *AdaptiveConfig
+		// Questions is the question of the quiz, nil mean the default question
+		Questions []QuestionPayload
+		// Clock measure the deadline of the player and the closing time, nil mean the wall clock
+		Clock Clock
+	}
+
+	// AsyncGame is self-paced quiz. Each player get their own question sequence
+	// and timer after joining, there is no start step from the host.
+	// The results are released when the quiz is closed
+	AsyncGame struct {
+		mu              sync.RWMutex
+		sessions        map[string]*asyncSession
+		state           State
+		questions       []QuestionPayload
+		timePerQuestion time.Duration
+		timeLimit       time.Duration
+		closesAt        time.Time
+		adaptive        *AdaptiveConfig
+		clock           Clock
+		internalStream  chan *internalAction
+		externalStream  chan *GameState
+		// stopStream is closed by Close, the timer of the player is stopped
+		stopStream chan bool
+		stopOnce   sync.Once
+		// answers is recorded when the player answer or run out of time
+		answers []AnswerRecord
+	}
+
+	// asyncSession is the progress of a player in the self-paced quiz
+	asyncSession struct {
+		index    int
+		point    int
+		deadline time.Time
+		// sent is the index of the question already sent to the player
+		sent             int
+		sentAt           time.Time
+		questionDeadline time.Time
+		finished         bool
+		// selector and the picked questions is only set in the adaptive difficulty
+		selector  *adaptiveSelector
+		questions []QuestionPayload
+	}
+
+	asyncTimeout struct {
+		name  string
+		index int
+	}
+
+	// PlayerEvent is the event for a single player
+	PlayerEvent struct {
+		Name  string
+		Event protoEvent
+	}
+)
+
+// SetQuestions is not supported, the self-paced quiz is started when it is created
+func (g *AsyncGame) SetQuestions([]QuestionPayload) error {
+	return errors.New("the self-paced quiz keep its questions")
+}
+
+// NewAsyncGame is ...
+func NewAsyncGame(cfg AsyncConfig) *AsyncGame {
+	if cfg.TimePerQuestion <= 0 {
+		cfg.TimePerQuestion = DefaultTimePerRound
+	}
+
+	if cfg.Clock == nil {
+		cfg.Clock = RealClock
+	}
+
+	if cfg.ClosesAt.IsZero() {
+		cfg.ClosesAt = cfg.Clock.Now().Add(DefaultAsyncWindow)
+	}
+
+	if cfg.Adaptive != nil && cfg.Adaptive.Seed == 0 {
+		adaptive := *cfg.Adaptive
+		adaptive.Seed = time.Now().UnixNano()
+		cfg.Adaptive = &adaptive
+	}
+
+	if cfg.Questions == nil {
+		cfg.Questions = defaultQuestions()
+	}
+
+	g := &AsyncGame{
+		sessions:        map[string]*asyncSession{},
+		state:           OnProgress,
+		questions:       cfg.Questions,
+		timePerQuestion: cfg.TimePerQuestion,
+		timeLimit:       cfg.TimeLimit,
+		closesAt:        cfg.ClosesAt,
+		adaptive:        cfg.Adaptive,
+		clock:           cfg.Clock,
+		internalStream:  make(chan *internalAction),
+		externalStream:  make(chan *GameState, 100),
+		stopStream:      make(chan bool),
+	}
+
+	go g.listenInternalStream()
+
+	g.clock.AfterFunc(g.closesAt.Sub(g.clock.Now()), func() {
+		g.setAction(closeQuiz, nil)
+	})
+
+	return g
+}
+
+// setAction is dropped when the quiz is closed
+func (g *AsyncGame) setAction(action action, payload any) {
+	select {
+	case g.internalStream <- &internalAction{
+		action:  action,
+		payload: payload,
+	}:
+	case <-g.stopStream:
+	}
+}
+
+// Close stop the goroutine of the quiz, the result of the quiz is kept
+func (g *AsyncGame) Close() {
+	g.stopOnce.Do(func() {
+		close(g.stopStream)
+	})
+}
+
+func (g *AsyncGame) listenInternalStream() {
+	for {
+		var res *internalAction
+		select {
+		case <-g.stopStream:
+			return
+		case res = <-g.internalStream:
+		}
+
+		switch res.action {
+		case join:
+			g.join(res.payload.(string))
+		case answerQuestion:
+			g.answer(res.payload.(SubmitAnswerPayload))
+		case timeoutQuestion:
+			g.timeout(res.payload.(asyncTimeout))
+		case closeQuiz:
+			g.mu.Lock()
+			g.state = Done
+			g.mu.Unlock()
+
+			g.externalStream <- &GameState{
+				State: Done,
+			}
+		}
+	}
+}
+
+// join start the question sequence of the player, the player who rejoin continue the sequence
+func (g *AsyncGame) join(name string) {
+	g.mu.Lock()
+	if g.state == Done {
+		g.mu.Unlock()
+		g.sendMessage(name, "the quiz is closed")
+		return
+	}
+
+	session, ok := g.sessions[name]
+	if !ok {
+		session = &asyncSession{
+			deadline: g.closesAt,
+			sent:     -1,
+		}
+		if limit := g.clock.Now().Add(g.timeLimit); g.timeLimit > 0 && limit.Before(session.deadline) {
+			session.deadline = limit
+		}
+		if g.adaptive != nil {
+			session.selector = newAdaptiveSelector(g.questions, *g.adaptive, playerSeed(g.adaptive.Seed, name))
+		}
+		g.sessions[name] = session
+	}
+	deadline := session.deadline
+	g.mu.Unlock()
+
+	if !ok {
+		g.sendMessage(name, fmt.Sprintf("you have until %s to answer %d questions, %s for each question unless the question has its own time limit",
+			deadline.Format(time.TimeOnly), len(g.questions), g.timePerQuestion))
+		if g.adaptive != nil {
+			g.sendMessage(name, fmt.Sprintf("the question is picked by your accuracy, seed %d", g.adaptive.Seed))
+		}
+	}
+
+	g.sendQuestion(name)
+}
+
+// sendQuestion send the current question of the player, or finish the sequence
+func (g *AsyncGame) sendQuestion(name string) {
+	g.mu.Lock()
+	session := g.sessions[name]
+	now := g.clock.Now()
+
+	if session.finished {
+		g.mu.Unlock()
+		g.sendMessage(name, g.finishedMessage())
+		return
+	}
+
+	question, ok := g.questionAt(session)
+	if !ok || !now.Before(session.deadline) {
+		session.finished = true
+		g.mu.Unlock()
+		g.sendMessage(name, g.finishedMessage())
+		return
+	}
+
+	// the rejoining player get the same question with the same timer
+	index := session.index
+	newQuestion := session.sent != index
+	if newQuestion {
+		session.sent = index
+		session.sentAt = now
+		session.questionDeadline = now.Add(question.timeOf(g.timePerQuestion))
+		if session.deadline.Before(session.questionDeadline) {
+			session.questionDeadline = session.deadline
+		}
+	}
+	deadline := session.questionDeadline
+	g.mu.Unlock()
+
+	g.externalStream <- &GameState{
+		State: OnProgress,
+		payload: PlayerEvent{
+			Name: name,
+			Event: QuestionEvent{
+				Round:      index + 1,
+				TotalRound: len(g.questions),
+				Question:   question.question,
+				Options:    question.choices(),
+				Deadline:   deadline,
+				Difficulty: DifficultyName(question.level()),
+				TimeLimit:  question.timeOf(g.timePerQuestion),
+				Points:     PointPerAnswer * question.weightOf(),
+			},
+		},
+	}
+
+	if newQuestion {
+		g.clock.AfterFunc(deadline.Sub(g.clock.Now()), func() {
+			g.setAction(timeoutQuestion, asyncTimeout{name: name, index: index})
+		})
+	}
+}
+
+func (g *AsyncGame) answer(payload SubmitAnswerPayload) {
+	g.mu.Lock()
+	session, ok := g.sessions[payload.Name]
+	if !ok || session.finished || g.state == Done || session.sent != session.index {
+		g.mu.Unlock()
+		return
+	}
+
+	question, _ := g.questionAt(session)
+	if err := question.validAnswer(payload.Answer); err != nil {
+		g.mu.Unlock()
+		g.sendMessage(payload.Name, err.Error())
+		return
+	}
+
+	// the answer is not revealed until the results are released
+	correct := payload.Answer == question.answer
+	if correct {
+		session.point += PointPerAnswer * question.weightOf()
+	}
+	session.record(correct)
+	g.answers = append(g.answers, AnswerRecord{
+		Round:         session.index + 1,
+		Question:      question.question,
+		CorrectAnswer: question.answer,
+		Player:        payload.Name,
+		Answer:        payload.Answer,
+		Correct:       correct,
+		Latency:       g.clock.Now().Sub(session.sentAt),
+	})
+	session.index++
+	g.mu.Unlock()
+
+	g.sendMessage(payload.Name, "answer recorded")
+	g.sendQuestion(payload.Name)
+}
+
+func (g *AsyncGame) timeout(t asyncTimeout) {
+	g.mu.Lock()
+	session := g.sessions[t.name]
+	if session.finished || g.state == Done || session.index != t.index {
+		g.mu.Unlock()
+		return
+	}
+	session.record(false)
+	question, _ := g.questionAt(session)
+	g.answers = append(g.answers, AnswerRecord{
+		Round:         session.index + 1,
+		Question:      question.question,
+		CorrectAnswer: question.answer,
+		Player:        t.name,
+	})
+	session.index++
+	g.mu.Unlock()
+
+	g.sendMessage(t.name, "time is up")
+	g.sendQuestion(t.name)
+}
+
+// questionAt is the current question of the player, it must be called when g.mu is locked
+func (g *AsyncGame) questionAt(session *asyncSession) (QuestionPayload, bool) {
+	if session.selector == nil {
+		if session.index >= len(g.questions) {
+			return QuestionPayload{}, false
+		}
+
+		return g.questions[session.index], true
+	}
+
+	for len(session.questions) <= session.index {
+		question, ok := session.selector.next()
+		if !ok {
+			return QuestionPayload{}, false
+		}
+		session.questions = append(session.questions, question)
+	}
+
+	return session.questions[session.index], true
+}
+
+// record the answer for the adaptive difficulty
+func (s *asyncSession) record(correct bool) {
+	if s.selector != nil {
+		s.selector.record(correct)
+	}
+}
+
+func (g *AsyncGame) finishedMessage() string {
+	return fmt.Sprintf("you have finished the quiz, the results are released at %s", g.closesAt.Format(time.TimeOnly))
+}
+
+func (g *AsyncGame) sendMessage(name, message string) {
+	g.externalStream <- &GameState{
+		State: OnProgress,
+		payload: BroadcastPersonalPayload{
+			Name:    name,
+			Message: message,
+		},
+	}
+}
+
+// Start is no-op, the self-paced quiz is started when the player join
+func (g *AsyncGame) Start() {}
+
+// AddPlayer ...
+func (g *AsyncGame) AddPlayer(name string) {
+	g.setAction(join, name)
+}
+
+// RemovePlayer keep the progress of the player, the timer is still running
+func (g *AsyncGame) RemovePlayer(string) {}
+
+// SubmitAnswer ...
+func (g *AsyncGame) SubmitAnswer(answer SubmitAnswerPayload) {
+	if g.CanAnswer(answer.Name) != nil {
+		return
+	}
+
+	g.setAction(answerQuestion, answer)
+}
+
+// Buzz ...
+func (g *AsyncGame) Buzz(BuzzPayload) error {
+	return fmt.Errorf("buzzer is only available in %s mode", BuzzerMode)
+}
+
+// UseLifeline ...
+func (g *AsyncGame) UseLifeline(LifelinePayload) error {
+	return fmt.Errorf("lifeline is not available in %s mode", AsyncMode)
+}
+
+// RequestHint ...
+func (g *AsyncGame) RequestHint(string) error {
+	return fmt.Errorf("hint is not available in %s mode", AsyncMode)
+}
+
+// Lifelines is always nil, there is no lifeline in the self-paced quiz
+func (g *AsyncGame) Lifelines() []LifelineUsage { return nil }
+
+// CanAnswer return the reason when the player can't answer the question
+func (g *AsyncGame) CanAnswer(name string) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.state == Done {
+		return errors.New("the quiz is closed")
+	}
+
+	session, ok := g.sessions[name]
+	if !ok {
+		return errors.New("you are not taking the quiz")
+	}
+
+	if session.finished {
+		return errors.New(g.finishedMessage())
+	}
+
+	return nil
+}
+
+// Answers is empty until the quiz is closed, the answer is ordered by the question and the player
+func (g *AsyncGame) Answers() []AnswerRecord {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.state != Done {
+		return nil
+	}
+
+	answers := append([]AnswerRecord{}, g.answers...)
+	sort.SliceStable(answers, func(i, j int) bool {
+		if answers[i].Round != answers[j].Round {
+			return answers[i].Round < answers[j].Round
+		}
+		return answers[i].Player < answers[j].Player
+	})
+
+	return answers
+}
+
+// ClosesAt is the time the results are released
+func (g *AsyncGame) ClosesAt() time.Time { return g.closesAt }
+
+// ListenStream ...
+func (g *AsyncGame) ListenStream() <-chan *GameState { return g.externalStream }
+
+// Scores is empty until the quiz is closed
+func (g *AsyncGame) Scores() []PlayerScore {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	players := []PlayerScore{}
+	if g.state != Done {
+		return players
+	}
+
+	for name, session := range g.sessions {
+		players = append(players, PlayerScore{
+			Name:  name,
+			Point: session.point,
+		})
+	}
+
+	sort.Slice(players, func(i, j int) bool {
+		if players[i].Point != players[j].Point {
+			return players[i].Point > players[j].Point
+		}
+
+		return players[i].Name < players[j].Name
+	})
+
+	return players
+}
+
+// TeamScores is always nil, the self-paced quiz is not played in team
+func (g *AsyncGame) TeamScores() []TeamScore { return nil }
+
+// Snapshot ...
+func (g *AsyncGame) Snapshot() GameSnapshot {
+	scores := g.Scores()
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return GameSnapshot{
+		Mode:       AsyncMode,
+		State:      g.state,
+		TotalRound: len(g.questions),
+		Scores:     scores,
+	}
+}
+
+// GetState print the progress of each player, the point is printed when the quiz is closed
+func (g *AsyncGame) GetState() {
+	snapshot := g.Snapshot()
+	if snapshot.State == Done {
+		fmt.Println("=== final point ===")
+		for i := 0; i < len(snapshot.Scores); i++ {
+			fmt.Printf("player: %v point %v\n", snapshot.Scores[i].Name, snapshot.Scores[i].Point)
+		}
+		return
+	}
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	fmt.Println("=== progress ===")
+	for name, session := range g.sessions {
+		fmt.Printf("player: %v answered %v/%v\n", name, session.index, len(g.questions))
+	}
+}
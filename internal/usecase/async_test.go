@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAsyncGameClock(t *testing.T) {
+	clock := NewManualClock(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	start := clock.Now()
+	game := NewAsyncGame(AsyncConfig{
+		TimePerQuestion: 5 * time.Second,
+		TimeLimit:       12 * time.Second,
+		ClosesAt:        start.Add(time.Minute),
+		Questions:       []QuestionPayload{{question: "q1", answer: "Y"}, {question: "q2", answer: "N"}, {question: "q3", answer: "Y"}},
+		Clock:           clock,
+	})
+	defer game.Close()
+	h := &harness{t: t, clock: clock}
+
+	// next skip the event until the question or the message of the player
+	next := func() any {
+		t.Helper()
+
+		for {
+			select {
+			case state := <-game.ListenStream():
+				if state.State == Done {
+					return Done
+				}
+				if event, ok := state.payload.(PlayerEvent); ok {
+					return event.Event
+				}
+				if message, ok := state.payload.(BroadcastPersonalPayload); ok {
+					return message.Message
+				}
+			case <-time.After(idleTimeout):
+				t.Fatalf("no event after %s", idleTimeout)
+				return nil
+			}
+		}
+	}
+	question := func(want time.Time) {
+		t.Helper()
+
+		for {
+			if event, ok := next().(QuestionEvent); ok {
+				if !event.Deadline.Equal(want) {
+					t.Errorf("round %d deadline = %s, want %s", event.Round, event.Deadline, want)
+				}
+				return
+			}
+		}
+	}
+
+	game.AddPlayer("ann")
+	question(start.Add(5 * time.Second))
+	h.waitTimer(start.Add(5 * time.Second))
+
+	clock.Advance(2 * time.Second)
+	game.SubmitAnswer(SubmitAnswerPayload{Name: "ann", Answer: "Y"})
+	question(start.Add(7 * time.Second))
+
+	// the timer of the answered question is ignored
+	clock.Advance(3 * time.Second)
+	h.waitTimer(start.Add(7 * time.Second))
+
+	// the last question is cut by the time limit of the player
+	clock.Advance(2 * time.Second)
+	question(start.Add(12 * time.Second))
+	h.waitTimer(start.Add(12 * time.Second))
+
+	clock.Advance(5 * time.Second)
+	if message := next(); message != "time is up" {
+		t.Errorf("message = %v, want time is up", message)
+	}
+	next()
+
+	clock.Advance(time.Minute)
+	for next() != Done {
+	}
+
+	want := []AnswerRecord{
+		{Round: 1, Question: "q1", CorrectAnswer: "Y", Player: "ann", Answer: "Y", Correct: true, Latency: 2 * time.Second},
+		{Round: 2, Question: "q2", CorrectAnswer: "N", Player: "ann"},
+		{Round: 3, Question: "q3", CorrectAnswer: "Y", Player: "ann"},
+	}
+	if got := game.Answers(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Answers() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+type (
+	// Question is the question written in the bank file
+	Question struct {
+		// ID is optional, the question is identified by the text when it is empty
+		ID       string   `json:"id,omitempty"`
+		Question string   `json:"question"`
+		Options  []string `json:"options,omitempty"`
+		// Answer is Y or N for the true or false question, or the key of the option, e.g. B
+		Answer      string   `json:"answer"`
+		Explanation string   `json:"explanation,omitempty"`
+		Hints       []string `json:"hints,omitempty"`
+		Difficulty  string   `json:"difficulty,omitempty"`
+		Category    string   `json:"category,omitempty"`
+		Tags        []string `json:"tags,omitempty"`
+		// TimeLimit is the seconds to answer the question, zero is the time per round of the game
+		TimeLimit int `json:"time_limit,omitempty"`
+		// Weight multiply the point of the correct answer, zero is 1
+		Weight int `json:"weight,omitempty"`
+	}
+
+	// QuestionBank is the file of the questions, the categories is optional.
+	// When the categories is declared, the category of the question must be one of them
+	QuestionBank struct {
+		Name       string     `json:"name"`
+		Categories []string   `json:"categories,omitempty"`
+		Questions  []Question `json:"questions"`
+	}
+)
+
+// LoadBank read the bank file, or every json file in the directory as one bank
+func LoadBank(path string) (*QuestionBank, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return readBank(path)
+	}
+
+	files, err := filepath.Glob(filepath.Join(path, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no question bank found in %s", path)
+	}
+
+	bank := &QuestionBank{Name: filepath.Base(path)}
+	for _, file := range files {
+		b, err := readBank(file)
+		if err != nil {
+			return nil, err
+		}
+
+		bank.merge(b)
+	}
+
+	return bank, nil
+}
+
+func readBank(path string) (*QuestionBank, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	bank := &QuestionBank{}
+	if err := json.Unmarshal(data, bank); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if bank.Name == "" {
+		bank.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	return bank, nil
+}
+
+// merge the categories and the questions of the other bank
+func (b *QuestionBank) merge(other *QuestionBank) {
+	for _, category := range other.Categories {
+		if !contains(b.Categories, category) {
+			b.Categories = append(b.Categories, category)
+		}
+	}
+
+	// the category of the bank without categories is not checked
+	for _, question := range other.Questions {
+		if len(other.Categories) == 0 && question.Category != "" && !contains(b.Categories, question.Category) {
+			b.Categories = append(b.Categories, question.Category)
+		}
+	}
+
+	b.Questions = append(b.Questions, other.Questions...)
+}
+
+// Save write the bank as json file
+func (b *QuestionBank) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Payloads convert the questions to the game question, every invalid question is returned in the error
+func (b *QuestionBank) Payloads() ([]QuestionPayload, error) {
+	payloads := []QuestionPayload{}
+	errs := []error{}
+	for i, question := range b.Questions {
+		payload, err := question.payload()
+		if err == nil && len(b.Categories) > 0 && question.Category != "" && !contains(b.Categories, question.Category) {
+			err = fmt.Errorf("category %s is not declared in the bank", question.Category)
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("question %d %q: %w", i+1, question.Question, err))
+			continue
+		}
+
+		payloads = append(payloads, payload)
+	}
+
+	if len(payloads) == 0 && len(errs) == 0 {
+		errs = append(errs, errors.New("the bank has no question"))
+	}
+
+	return payloads, errors.Join(errs...)
+}
+
+// Validate return the reason when the question can't be played
+func (q Question) Validate() error {
+	_, err := q.payload()
+
+	return err
+}
+
+func (q Question) payload() (QuestionPayload, error) {
+	if strings.TrimSpace(q.Question) == "" {
+		return QuestionPayload{}, errors.New("question is empty")
+	}
+
+	difficulty, err := ParseDifficulty(q.Difficulty)
+	if err != nil {
+		return QuestionPayload{}, err
+	}
+
+	payload := QuestionPayload{
+		id:          q.ID,
+		question:    q.Question,
+		options:     q.Options,
+		answer:      strings.ToUpper(strings.TrimSpace(q.Answer)),
+		explanation: q.Explanation,
+		hints:       q.Hints,
+		difficulty:  difficulty,
+		category:    q.Category,
+		tags:        q.Tags,
+		timeLimit:   time.Duration(q.TimeLimit) * time.Second,
+		weight:      q.Weight,
+	}
+
+	if len(q.Options) == 1 {
+		return QuestionPayload{}, errors.New("multiple-choice question must have at least 2 options")
+	}
+
+	if q.TimeLimit < 0 {
+		return QuestionPayload{}, fmt.Errorf("time limit %d must not be negative", q.TimeLimit)
+	}
+
+	if q.Weight < 0 {
+		return QuestionPayload{}, fmt.Errorf("weight %d must not be negative", q.Weight)
+	}
+
+	if err := payload.validAnswer(payload.answer); err != nil {
+		return QuestionPayload{}, fmt.Errorf("answer %q must be one of %s", q.Answer, strings.Join(payload.keys(), ", "))
+	}
+
+	return payload, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+type (
+	// BankStats is total question by category and difficulty
+	BankStats struct {
+		Total int
+		// Categories is sorted by the name, the question without category is in the empty category
+		Categories []CategoryStats
+	}
+
+	// CategoryStats is ...
+	CategoryStats struct {
+		Category string
+		Total    int
+		// Difficulty is total question by the difficulty name, the invalid difficulty is counted as it is
+		Difficulty map[string]int
+	}
+)
+
+// Stats is ...
+func (b *QuestionBank) Stats() BankStats {
+	categories := map[string]*CategoryStats{}
+	for _, question := range b.Questions {
+		category, ok := categories[question.Category]
+		if !ok {
+			category = &CategoryStats{Category: question.Category, Difficulty: map[string]int{}}
+			categories[question.Category] = category
+		}
+
+		difficulty := strings.ToLower(question.Difficulty)
+		if level, err := ParseDifficulty(question.Difficulty); err == nil {
+			difficulty = DifficultyName(level)
+		}
+
+		category.Total++
+		category.Difficulty[difficulty]++
+	}
+
+	stats := BankStats{Total: len(b.Questions)}
+	for _, category := range categories {
+		stats.Categories = append(stats.Categories, *category)
+	}
+	sort.Slice(stats.Categories, func(i, j int) bool {
+		return stats.Categories[i].Category < stats.Categories[j].Category
+	})
+
+	return stats
+}
@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	quiz "github.com/elangreza14/grpc-quiz/proto"
+)
+
+// botMinDelay is the fastest answer of the bot
+const botMinDelay = time.Second
+
+// DefaultBotAccuracy is the accuracy of the bot added by the host when it is not set
+const DefaultBotAccuracy = "0.6"
+
+type (
+	// Bot is computer player, it answer the question after a random delay
+	Bot struct {
+		Name string
+		// Accuracy is the chance of the correct answer, from 0 to 1
+		Accuracy float64
+		// Reaction is the delay of the answer, zero mean random time before the deadline
+		Reaction LatencyDist
+		rand     *rand.Rand
+	}
+
+	// BotConfig is the bots added to the room by the host
+	BotConfig struct {
+		Count int
+		// Accuracy is picked for each bot from the range
+		Accuracy AccuracyRange
+		Reaction LatencyDist
+		// Seed of the accuracy and the answer of the bots, zero is random
+		Seed int64
+	}
+)
+
+// NewBot is ...
+func NewBot(name string, accuracy float64, seed int64) *Bot {
+	return &Bot{
+		Name:     name,
+		Accuracy: accuracy,
+		rand:     rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Join the bot to the room and the smallest team, the bot play until the room is done
+func (b *Bot) Join(ctx context.Context, room *Room) error {
+	ch := make(chan *quiz.StreamResponse, 100)
+	if _, err := room.join(b.Name, "", ch, true); err != nil {
+		return err
+	}
+
+	go b.play(ctx, room, ch)
+
+	return nil
+}
+
+// play keep reading the stream while the answer is waiting, so the room never drop the bot as the slow player.
+// The answer is picked when the question is received, the rand of the bot is only used by this goroutine
+func (b *Bot) play(ctx context.Context, room *Room, ch <-chan *quiz.StreamResponse) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-room.Done():
+			return
+		case res := <-ch:
+			question := res.GetQuestion()
+			if question == nil {
+				continue
+			}
+
+			answer := SubmitAnswerPayload{
+				Name:   b.Name,
+				Answer: b.answer(room, question.Question),
+			}
+			time.AfterFunc(b.delay(question.Deadline.AsTime()), func() {
+				select {
+				case <-ctx.Done():
+					return
+				case <-room.Done():
+					return
+				default:
+				}
+
+				room.PublishQueue(&Event{EventType: SubmitAnswer, Payload: answer})
+			})
+		}
+	}
+}
+
+// delay is the reaction of the bot, or random time before the deadline of the question
+func (b *Bot) delay(deadline time.Time) time.Duration {
+	if b.Reaction.kind != "" {
+		return b.Reaction.Sample(b.rand)
+	}
+
+	remaining := time.Until(deadline) * 6 / 10
+	if remaining <= botMinDelay {
+		return botMinDelay
+	}
+
+	return botMinDelay + time.Duration(b.rand.Int63n(int64(remaining-botMinDelay)))
+}
+
+// answer is the key of the option, the wrong answer is picked randomly
+func (b *Bot) answer(room *Room, question string) string {
+	game, ok := room.Game.(*GamePlay)
+	if !ok {
+		return trueFalseKeys[b.rand.Intn(len(trueFalseKeys))]
+	}
+
+	q, ok := game.questionOf(question)
+	if !ok {
+		return trueFalseKeys[b.rand.Intn(len(trueFalseKeys))]
+	}
+
+	if b.rand.Float64() < b.Accuracy {
+		return q.answer
+	}
+
+	wrong := q.wrongKeys()
+	return wrong[b.rand.Intn(len(wrong))]
+}
+
+// ParseBotConfig parse the accuracy and the reaction of the bots, the empty accuracy is DefaultBotAccuracy
+// and the empty reaction is random time before the deadline
+func ParseBotConfig(count int, accuracy, reaction string) (BotConfig, error) {
+	if accuracy == "" {
+		accuracy = DefaultBotAccuracy
+	}
+
+	cfg := BotConfig{Count: count}
+	var err error
+	if cfg.Accuracy, err = ParseAccuracyRange(accuracy); err != nil {
+		return BotConfig{}, err
+	}
+
+	if reaction != "" {
+		if cfg.Reaction, err = ParseLatencyDist(reaction); err != nil {
+			return BotConfig{}, err
+		}
+	}
+
+	return cfg, nil
+}
+
+// canAddBots is error when the bot can't play the mode, the bot never buzz and has no own timer
+func canAddBots(mode string) error {
+	if mode == AsyncMode || mode == BuzzerMode {
+		return fmt.Errorf("the bot can't play in %s mode", mode)
+	}
+
+	return nil
+}
+
+// AddBots join the bots to the room before the game is started, the bot is named bot-1, bot-2 and so on
+func (r *Room) AddBots(cfg BotConfig) ([]string, error) {
+	if cfg.Count <= 0 {
+		return nil, errors.New("total bot must be positive")
+	}
+
+	if r.Started() {
+		return nil, errors.New("game already started")
+	}
+
+	mode := AsyncMode
+	if game, ok := r.Game.(*GamePlay); ok {
+		mode = game.gameMode().Name()
+	}
+	if err := canAddBots(mode); err != nil {
+		return nil, err
+	}
+
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rnd := rand.New(rand.NewSource(seed))
+
+	names := []string{}
+	for i := 1; len(names) < cfg.Count; i++ {
+		name := fmt.Sprintf("bot-%d", i)
+		if _, ok := r.GetPlayerDetail(name); ok {
+			continue
+		}
+
+		bot := NewBot(name, cfg.Accuracy.Sample(rnd), rnd.Int63())
+		bot.Reaction = cfg.Reaction
+		if err := bot.Join(context.Background(), r); errors.Is(err, ErrPlayerExists) {
+			continue
+		} else if err != nil {
+			return names, err
+		}
+		names = append(names, name)
+	}
+
+	return names, nil
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	quiz "github.com/elangreza14/grpc-quiz/proto"
+)
+
+func TestRoomAddBots(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		wantErr bool
+	}{
+		{name: "the bot play the classic game", mode: ClassicMode},
+		{name: "the bot play the elimination game", mode: EliminationMode},
+		{name: "the bot never buzz", mode: BuzzerMode, wantErr: true},
+		{name: "the bot has no own timer in the self-paced quiz", mode: AsyncMode, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := BotConfig{Count: 2}
+			if _, err := NewRoom("room-1", RoomConfig{Mode: tt.mode, Bots: cfg}); (err != nil) != tt.wantErr {
+				t.Errorf("NewRoom() with the bots error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			room, err := NewRoom("room-1", RoomConfig{Mode: tt.mode})
+			if err != nil {
+				t.Fatalf("NewRoom() error = %v", err)
+			}
+
+			bots, err := room.AddBots(cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddBots() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && len(bots) != cfg.Count {
+				t.Errorf("AddBots() = %v, want %d bots", bots, cfg.Count)
+			}
+		})
+	}
+}
+
+func TestBotJoinTakenName(t *testing.T) {
+	room, err := NewRoom("room-1", RoomConfig{})
+	if err != nil {
+		t.Fatalf("NewRoom() error = %v", err)
+	}
+
+	// the human took the name of the bot first
+	if _, err := room.Join("bot-1", ""); err != nil {
+		t.Fatalf("Join() error = %v", err)
+	}
+	if err := NewBot("bot-1", 1, 1).Join(context.Background(), room); !errors.Is(err, ErrPlayerExists) {
+		t.Errorf("Bot.Join() error = %v, want %v", err, ErrPlayerExists)
+	}
+	if room.IsBot("bot-1") {
+		t.Error("the human bot-1 is tagged as the bot")
+	}
+
+	bots, err := room.AddBots(BotConfig{Count: 1})
+	if err != nil {
+		t.Fatalf("AddBots() error = %v", err)
+	}
+	if !reflect.DeepEqual(bots, []string{"bot-2"}) || !room.IsBot("bot-2") || room.IsBot("bot-1") {
+		t.Errorf("AddBots() = %v, want only bot-2 tagged as the bot", bots)
+	}
+}
+
+func TestBotReadWhileWaiting(t *testing.T) {
+	room, err := NewRoom("room-1", RoomConfig{})
+	if err != nil {
+		t.Fatalf("NewRoom() error = %v", err)
+	}
+
+	// the bot wait an hour before the answer
+	cfg, err := ParseBotConfig(1, "1", "1h")
+	if err != nil {
+		t.Fatalf("ParseBotConfig() error = %v", err)
+	}
+	if _, err := room.AddBots(cfg); err != nil {
+		t.Fatalf("AddBots() error = %v", err)
+	}
+
+	stream, _ := room.players.Load("bot-1")
+	ch := stream.(chan *quiz.StreamResponse)
+	ch <- QuestionEvent{Round: 1, Question: "q1", Deadline: time.Now().Add(time.Hour)}.toProto()
+
+	// the message after the question is still read, the bot is not removed as the slow player
+	for i := 0; i <= cap(ch); i++ {
+		room.BroadcastToAllPlayer("message")
+
+		deadline := time.Now().Add(idleTimeout)
+		for len(ch) > 0 {
+			if time.Now().After(deadline) {
+				t.Fatalf("the bot doesn't read the stream after %d messages", i)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	if !room.IsBot("bot-1") {
+		t.Error("bot-1 is removed from the room")
+	}
+	if _, ok := room.GetPlayerDetail("bot-1"); !ok {
+		t.Error("bot-1 is removed from the room")
+	}
+}
@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// BuzzerTieWindow is the window after the first buzz, every buzz received
+	// in the window is ordered by the time it is received by the server
+	BuzzerTieWindow = 150 * time.Millisecond
+	// BuzzerAnswerTime is the time for the holder of the buzzer to answer
+	BuzzerAnswerTime = 5 * time.Second
+)
+
+// ErrNotBuzzed is returned when the player answer without holding the buzzer,
+// the message of the player is a buzz instead of an answer
+var ErrNotBuzzed = errors.New("press (B) to buzz before answering")
+
+type (
+	// BuzzPayload ...
+	BuzzPayload struct {
+		Name string
+		// ReceivedAt is the time the buzz is received by the server
+		ReceivedAt time.Time
+	}
+
+	// BuzzerEvent is emitted when the buzzer is taken or open again
+	BuzzerEvent struct {
+		Round     int
+		Holder    string
+		LockedOut []string
+	}
+
+	// buzzerMode is pub-quiz rule set. The first player who buzz get to answer,
+	// wrong answer lock the player out and the buzzer is open for the others
+	buzzerMode struct {
+		mu        sync.RWMutex
+		questions []QuestionPayload
+		round     int
+		open      bool
+		buzzes    []BuzzPayload
+		holder    string
+		lockedOut map[string]bool
+		players   map[string]bool
+	}
+)
+
+func newBuzzerMode(questions []QuestionPayload) *buzzerMode {
+	return &buzzerMode{
+		questions: questions,
+		lockedOut: map[string]bool{},
+		players:   map[string]bool{},
+	}
+}
+
+func (m *buzzerMode) Name() string { return BuzzerMode }
+
+func (m *buzzerMode) Start(players []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, player := range players {
+		m.players[player] = true
+	}
+}
+
+func (m *buzzerMode) Next(round int) (QuestionPayload, bool) {
+	if round >= len(m.questions) {
+		return QuestionPayload{}, false
+	}
+
+	return m.questions[round], true
+}
+
+func (m *buzzerMode) CanAnswer(player string) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.lockedOut[player] {
+		return errors.New("you are locked out in this round")
+	}
+
+	if m.holder != player {
+		return ErrNotBuzzed
+	}
+
+	return nil
+}
+
+// EndRound close the buzzer until the next round is started
+func (m *buzzerMode) EndRound(RoundSummary) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.open = false
+	m.holder = ""
+
+	return nil
+}
+
+// StartRound reset the buzzer for the round
+func (m *buzzerMode) StartRound(round int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.round = round
+	m.open = true
+	m.buzzes = nil
+	m.holder = ""
+	m.lockedOut = map[string]bool{}
+}
+
+// Buzz return true when the buzz open the tie window
+func (m *buzzerMode) Buzz(buzz BuzzPayload) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.players[buzz.Name] {
+		return false, errors.New("you are not playing in this game")
+	}
+
+	if !m.open {
+		return false, errors.New("the buzzer is closed, wait for the next question")
+	}
+
+	if m.lockedOut[buzz.Name] {
+		return false, errors.New("you are locked out in this round")
+	}
+
+	if m.holder == buzz.Name {
+		return false, errors.New("you are holding the buzzer, answer the question")
+	}
+
+	if m.holder != "" {
+		return false, fmt.Errorf("%s is holding the buzzer", m.holder)
+	}
+
+	for _, b := range m.buzzes {
+		if b.Name == buzz.Name {
+			return false, nil
+		}
+	}
+
+	m.buzzes = append(m.buzzes, buzz)
+
+	return len(m.buzzes) == 1, nil
+}
+
+// Resolve close the tie window, the earliest buzz hold the buzzer
+func (m *buzzerMode) Resolve(round int) (BuzzerEvent, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.open || round != m.round || m.holder != "" || len(m.buzzes) == 0 {
+		return BuzzerEvent{}, false
+	}
+
+	sort.SliceStable(m.buzzes, func(i, j int) bool {
+		if !m.buzzes[i].ReceivedAt.Equal(m.buzzes[j].ReceivedAt) {
+			return m.buzzes[i].ReceivedAt.Before(m.buzzes[j].ReceivedAt)
+		}
+
+		return m.buzzes[i].Name < m.buzzes[j].Name
+	})
+
+	m.holder = m.buzzes[0].Name
+	m.buzzes = nil
+
+	return m.event(), true
+}
+
+// Answer is called with the answer of the holder, it return true when the round is ended
+func (m *buzzerMode) Answer(player string, correct bool) (BuzzerEvent, bool, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.open || player != m.holder {
+		return BuzzerEvent{}, false, false
+	}
+
+	if correct {
+		return m.event(), true, true
+	}
+
+	event, ended := m.lockOut(player)
+
+	return event, ended, true
+}
+
+// Timeout lock the holder out when the holder doesn't answer in time
+func (m *buzzerMode) Timeout(round int, holder string) (BuzzerEvent, bool, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.open || round != m.round || holder != m.holder {
+		return BuzzerEvent{}, false, false
+	}
+
+	event, ended := m.lockOut(holder)
+
+	return event, ended, true
+}
+
+// lockOut must be called when m.mu is locked
+func (m *buzzerMode) lockOut(player string) (BuzzerEvent, bool) {
+	m.lockedOut[player] = true
+	m.holder = ""
+
+	ended := true
+	for name := range m.players {
+		if !m.lockedOut[name] {
+			ended = false
+		}
+	}
+
+	return m.event(), ended
+}
+
+// event must be called when m.mu is locked
+func (m *buzzerMode) event() BuzzerEvent {
+	lockedOut := []string{}
+	for name := range m.lockedOut {
+		lockedOut = append(lockedOut, name)
+	}
+	sort.Strings(lockedOut)
+
+	return BuzzerEvent{
+		Round:     m.round + 1,
+		Holder:    m.holder,
+		LockedOut: lockedOut,
+	}
+}
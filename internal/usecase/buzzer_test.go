@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBuzzerResolve(t *testing.T) {
+	at := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	buzz := func(name string, after time.Duration) BuzzPayload {
+		return BuzzPayload{Name: name, ReceivedAt: at.Add(after)}
+	}
+
+	tests := []struct {
+		name       string
+		lockedOut  []string
+		buzzes     []BuzzPayload
+		wantOpened []bool
+		wantHolder string
+	}{
+		{
+			name:       "the earliest received buzz hold the buzzer, not the first processed",
+			buzzes:     []BuzzPayload{buzz("bob", 50*time.Millisecond), buzz("ann", 20*time.Millisecond), buzz("cat", 140*time.Millisecond)},
+			wantOpened: []bool{true, false, false},
+			wantHolder: "ann",
+		},
+		{
+			name:       "the buzz received at the same time is ordered by the name",
+			buzzes:     []BuzzPayload{buzz("cat", 0), buzz("bob", 0), buzz("dan", 0)},
+			wantOpened: []bool{true, false, false},
+			wantHolder: "bob",
+		},
+		{
+			name:       "the second buzz of the player is ignored",
+			buzzes:     []BuzzPayload{buzz("bob", 30*time.Millisecond), buzz("ann", 40*time.Millisecond), buzz("bob", 0)},
+			wantOpened: []bool{true, false, false},
+			wantHolder: "bob",
+		},
+		{
+			name:       "the locked out player can't buzz",
+			lockedOut:  []string{"ann"},
+			buzzes:     []BuzzPayload{buzz("ann", 0), buzz("bob", 10*time.Millisecond)},
+			wantOpened: []bool{false, true},
+			wantHolder: "bob",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newBuzzerMode(nil)
+			m.Start([]string{"ann", "bob", "cat", "dan"})
+			m.StartRound(0)
+			for _, player := range tt.lockedOut {
+				m.lockedOut[player] = true
+			}
+
+			opened := []bool{}
+			for _, b := range tt.buzzes {
+				ok, _ := m.Buzz(b)
+				opened = append(opened, ok)
+			}
+			if !reflect.DeepEqual(opened, tt.wantOpened) {
+				t.Errorf("opened = %v, want %v", opened, tt.wantOpened)
+			}
+
+			event, ok := m.Resolve(0)
+			if !ok || event.Holder != tt.wantHolder {
+				t.Errorf("Resolve() = %+v %v, want holder %s", event, ok, tt.wantHolder)
+			}
+
+			// the buzz after the tie window is rejected by the holder
+			if _, err := m.Buzz(buzz("cat", time.Second)); err == nil {
+				t.Error("Buzz() after the holder is resolved, want error")
+			}
+		})
+	}
+}
+
+func TestGamePlayBuzzerTieWindow(t *testing.T) {
+	clock := NewManualClock(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	game, err := NewGamePlay(GameConfig{
+		Mode:      BuzzerMode,
+		Questions: []QuestionPayload{{question: "q1", answer: "Y"}},
+		Clock:     clock,
+	})
+	if err != nil {
+		t.Fatalf("NewGamePlay() error = %v", err)
+	}
+	for _, player := range []string{"ann", "bob", "cat"} {
+		game.AddPlayer(player)
+	}
+	game.Start()
+	h := &harness{t: t, clock: clock, game: game}
+
+	expect[QuestionEvent](h)
+	start := clock.Now()
+
+	// ann is processed first, bob is received earlier in the tie window
+	if err := game.Buzz(BuzzPayload{Name: "ann", ReceivedAt: start.Add(100 * time.Millisecond)}); err != nil {
+		t.Fatalf("Buzz() error = %v", err)
+	}
+	if err := game.Buzz(BuzzPayload{Name: "bob", ReceivedAt: start.Add(10 * time.Millisecond)}); err != nil {
+		t.Fatalf("Buzz() error = %v", err)
+	}
+	h.waitTimer(start.Add(BuzzerTieWindow))
+	clock.Advance(BuzzerTieWindow)
+
+	if event := expect[BuzzerEvent](h); event.Holder != "bob" {
+		t.Fatalf("holder = %s, want bob", event.Holder)
+	}
+
+	// the wrong answer lock bob out and open the buzzer for the others
+	h.answer("bob", "N")
+	if event := expect[BuzzerEvent](h); event.Holder != "" || !reflect.DeepEqual(event.LockedOut, []string{"bob"}) {
+		t.Errorf("event = %+v, want the open buzzer with bob locked out", event)
+	}
+
+	if err := game.CanAnswer("bob"); err == nil {
+		t.Error("CanAnswer(bob) after the wrong answer, want error")
+	}
+}
@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+type (
+	// Clock is the time of the game, the round timer and the deadline of the answer is measured by the clock
+	Clock interface {
+		Now() time.Time
+		NewTimer(d time.Duration) Timer
+		AfterFunc(d time.Duration, f func()) Timer
+	}
+
+	// Timer is the timer created by the clock, it behave like time.Timer
+	Timer interface {
+		C() <-chan time.Time
+		Stop() bool
+		Reset(d time.Duration) bool
+	}
+
+	realClock struct{}
+
+	realTimer struct {
+		*time.Timer
+	}
+
+	// ManualClock only move when it is advanced, the due timer is fired by Advance.
+	// It is used to replay and to test the game without waiting
+	ManualClock struct {
+		mu     sync.Mutex
+		now    time.Time
+		timers []*manualTimer
+	}
+
+	manualTimer struct {
+		clock  *ManualClock
+		when   time.Time
+		active bool
+		c      chan time.Time
+		f      func()
+	}
+)
+
+// RealClock is the wall clock, it is used when the game has no clock
+var RealClock Clock = realClock{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return realTimer{time.AfterFunc(d, f)}
+}
+
+func (t realTimer) C() <-chan time.Time { return t.Timer.C }
+
+// NewManualClock start the clock at the time
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+// Now is ...
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// NewTimer is ...
+func (c *ManualClock) NewTimer(d time.Duration) Timer {
+	return c.schedule(&manualTimer{clock: c, c: make(chan time.Time, 1)}, d)
+}
+
+// AfterFunc is ...
+func (c *ManualClock) AfterFunc(d time.Duration, f func()) Timer {
+	return c.schedule(&manualTimer{clock: c, f: f}, d)
+}
+
+func (c *ManualClock) schedule(t *manualTimer, d time.Duration) *manualTimer {
+	c.mu.Lock()
+	t.when = c.now.Add(d)
+	t.active = true
+	// the reset timer is still in the list until it is fired
+	found := false
+	for _, timer := range c.timers {
+		found = found || timer == t
+	}
+	if !found {
+		c.timers = append(c.timers, t)
+	}
+	c.mu.Unlock()
+
+	// the timer with zero or negative duration is fired immediately
+	c.Advance(0)
+
+	return t
+}
+
+// Advance move the clock and fire every due timer in the order of the time
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	now := c.now
+
+	due := []*manualTimer{}
+	active := []*manualTimer{}
+	for _, t := range c.timers {
+		switch {
+		case !t.active:
+		case !t.when.After(now):
+			t.active = false
+			due = append(due, t)
+		default:
+			active = append(active, t)
+		}
+	}
+	c.timers = active
+	c.mu.Unlock()
+
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].when.Before(due[j].when)
+	})
+
+	for _, t := range due {
+		if t.f != nil {
+			go t.f()
+			continue
+		}
+
+		select {
+		case t.c <- now:
+		default:
+		}
+	}
+}
+
+// Next is the time of the earliest timer, zero when there is no timer
+func (c *ManualClock) Next() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	next := time.Time{}
+	for _, t := range c.timers {
+		if t.active && (next.IsZero() || t.when.Before(next)) {
+			next = t.when
+		}
+	}
+
+	return next
+}
+
+func (t *manualTimer) C() <-chan time.Time { return t.c }
+
+func (t *manualTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	active := t.active
+	t.active = false
+
+	return active
+}
+
+func (t *manualTimer) Reset(d time.Duration) bool {
+	active := t.Stop()
+	t.clock.schedule(t, d)
+
+	return active
+}
@@ -0,0 +1,264 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RoundControl is the action of the host on the running game
+type RoundControl int
+
+const (
+	// PauseRound stop the countdown, the remaining time is kept until the round is resumed
+	PauseRound RoundControl = iota
+	// ResumeRound continue the countdown from the remaining time
+	ResumeRound
+	// SkipRound end the round now, the answer before the skip is counted
+	SkipRound
+	// ExtendRound add time to the round of every player
+	ExtendRound
+	// AdvanceRound start the next round when the game is in review
+	AdvanceRound
+)
+
+// DefaultExtendTime is the time added by the host when the time is not set
+const DefaultExtendTime = 10 * time.Second
+
+// ErrPaused is returned to the player who answer when the round is paused
+var ErrPaused = errors.New("the round is paused, wait for the host to resume")
+
+type (
+	// RoundPaused is emitted when the round is paused, or the paused round is extended
+	RoundPaused struct {
+		Round int
+		// Remaining is the time left of the question when the round is resumed
+		Remaining time.Duration
+	}
+
+	// RoundResumed is emitted when the round is resumed or extended, Deadline is the new deadline of the question
+	RoundResumed struct {
+		Round    int
+		Deadline time.Time
+		// Extended is the time added by the host, zero when the round is resumed
+		Extended time.Duration
+	}
+
+	// RoundReview is emitted after the result of the round, the game hold until the host start the next round
+	RoundReview struct {
+		Round      int
+		TotalRound int
+	}
+
+	roundControl struct {
+		action RoundControl
+		extend time.Duration
+		// done receive the result after the action is applied
+		done chan error
+	}
+)
+
+var roundControls = map[string]RoundControl{
+	"pause":  PauseRound,
+	"resume": ResumeRound,
+	"skip":   SkipRound,
+	"extend": ExtendRound,
+	"next":   AdvanceRound,
+}
+
+func (c RoundControl) String() string {
+	for name, control := range roundControls {
+		if control == c {
+			return name
+		}
+	}
+
+	return fmt.Sprintf("control(%d)", int(c))
+}
+
+// ParseRoundControl parse the command of the host, e.g. /pause or /extend 30, the extend is in seconds
+func ParseRoundControl(command string) (RoundControl, time.Duration, error) {
+	fields := strings.Fields(strings.TrimPrefix(strings.TrimSpace(command), "/"))
+	if len(fields) == 0 {
+		return 0, 0, errors.New("the command is empty")
+	}
+
+	control, ok := roundControls[strings.ToLower(fields[0])]
+	if !ok {
+		return 0, 0, fmt.Errorf("command %s not found, use /pause, /resume, /skip, /extend [seconds] or /next", fields[0])
+	}
+
+	if control != ExtendRound || len(fields) == 1 {
+		return control, 0, nil
+	}
+
+	seconds, err := strconv.Atoi(fields[1])
+	if err != nil || seconds <= 0 {
+		return 0, 0, fmt.Errorf("extend %s must be the positive seconds", fields[1])
+	}
+
+	return control, time.Duration(seconds) * time.Second, nil
+}
+
+// Control apply the action of the host and wait until it is applied, the extend is DefaultExtendTime when it is zero
+func (g *GamePlay) Control(action RoundControl, extend time.Duration) error {
+	if extend <= 0 {
+		extend = DefaultExtendTime
+	}
+
+	g.mu.RLock()
+	err := g.canControl(action)
+	g.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	g.setAction(controlRound, roundControl{action: action, extend: extend, done: done})
+
+	select {
+	case err := <-done:
+		return err
+	case <-g.stopStream:
+		return errors.New("the game is closed")
+	}
+}
+
+// canControl must be called when g.mu is locked
+func (g *GamePlay) canControl(action RoundControl) error {
+	if g.state != OnProgress {
+		return errors.New("the game is not on progress")
+	}
+
+	if action == AdvanceRound {
+		if !g.reviewing {
+			return errors.New("the game is not in review, the next round is started automatically")
+		}
+
+		return nil
+	}
+
+	if g.expected.playerRetries == nil || g.expected.closed {
+		return errors.New("there is no round on progress")
+	}
+
+	// the buzzer has its own timer for the holder
+	if _, ok := g.gameMode().(buzzer); ok && action != SkipRound {
+		return fmt.Errorf("only skip is available in %s mode", BuzzerMode)
+	}
+
+	switch {
+	case action == PauseRound && g.paused:
+		return errors.New("the round is already paused")
+	case action == ResumeRound && !g.paused:
+		return errors.New("the round is not paused")
+	}
+
+	return nil
+}
+
+// controlRound is called from the internal stream, so the action is applied between the round event.
+// The round may be ended after the action is sent, so the action is checked again
+func (g *GamePlay) controlRound(control roundControl) {
+	g.mu.Lock()
+	if err := g.canControl(control.action); err != nil {
+		g.mu.Unlock()
+		control.done <- err
+		return
+	}
+
+	round := g.expected.round + 1
+	var event any
+	switch control.action {
+	case PauseRound:
+		g.paused = true
+		g.pausedAt = g.clock.Now()
+		event = RoundPaused{Round: round, Remaining: g.expected.deadline.Sub(g.pausedAt)}
+	case ResumeRound:
+		g.paused = false
+		pausedFor := g.clock.Now().Sub(g.pausedAt)
+		g.expected.started = g.expected.started.Add(pausedFor)
+		g.extendDeadlines(pausedFor)
+		event = RoundResumed{Round: round, Deadline: g.expected.deadline}
+	case ExtendRound:
+		g.extendDeadlines(control.extend)
+		if g.paused {
+			event = RoundPaused{Round: round, Remaining: g.expected.deadline.Sub(g.pausedAt)}
+		} else {
+			event = RoundResumed{Round: round, Deadline: g.expected.deadline, Extended: control.extend}
+		}
+	case SkipRound:
+		g.paused = false
+		event = fmt.Sprintf("the host skipped question %d", round)
+	case AdvanceRound:
+		g.reviewing = false
+	}
+	question := g.expected
+	g.mu.Unlock()
+	control.done <- nil
+
+	if event != nil {
+		g.externalStream <- &GameState{
+			State:   OnProgress,
+			payload: event,
+		}
+	}
+
+	switch control.action {
+	case SkipRound:
+		g.endRoundEarly()
+	case AdvanceRound:
+		g.nextRound(question.round + 1)
+	case ResumeRound:
+		g.resetTimer()
+		g.scheduleHints(question)
+	default:
+		g.resetTimer()
+	}
+}
+
+// extendDeadlines move the deadline of the question and every player, it must be called when g.mu is locked
+func (g *GamePlay) extendDeadlines(d time.Duration) {
+	g.expected.deadline = g.expected.deadline.Add(d)
+	for name, deadline := range g.expected.deadlines {
+		g.expected.deadlines[name] = deadline.Add(d)
+	}
+	g.deadline = g.deadline.Add(d)
+}
+
+// resetTimer tell the round to read the deadline again, the countdown is stopped when the round is paused
+func (g *GamePlay) resetTimer() {
+	g.mu.RLock()
+	extend := g.expected.extend
+	g.mu.RUnlock()
+
+	select {
+	case extend <- true:
+	default:
+	}
+}
+
+// startReview hold the game after the round, the last round is not reviewed
+func (g *GamePlay) startReview() bool {
+	g.mu.Lock()
+	review := g.review && g.expected.round+1 < g.totalRound
+	g.reviewing = review
+	round := g.expected.round + 1
+	g.mu.Unlock()
+
+	if review {
+		g.externalStream <- &GameState{
+			State:   OnProgress,
+			payload: RoundReview{Round: round, TotalRound: g.totalRound},
+		}
+	}
+
+	return review
+}
+
+// Control is not available in the self-paced quiz, every player has its own timer
+func (g *AsyncGame) Control(RoundControl, time.Duration) error {
+	return fmt.Errorf("the host can't control the round in %s mode", AsyncMode)
+}
@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RandomCategory is the draw rule from every category
+const RandomCategory = "random"
+
+type (
+	// DrawRule pick total question from the category or the tag, both empty mean any question
+	DrawRule struct {
+		Category string
+		Tag      string
+		Count    int
+	}
+
+	// DrawConfig pick and shuffle the question of the game from the bank.
+	// The same seed with the same bank produce the same questions and options order
+	DrawConfig struct {
+		// Rules is empty mean every question is drawn
+		Rules []DrawRule
+		// NoRepeat exclude the question drawn in the last N games
+		NoRepeat int
+		// Seed is zero mean random seed
+		Seed int64
+	}
+)
+
+// ParseDrawRules parse the rule, e.g. science:4,history:3,random:3 or #math:2 for the tag
+func ParseDrawRules(s string) ([]DrawRule, error) {
+	rules := []DrawRule{}
+	if strings.TrimSpace(s) == "" {
+		return rules, nil
+	}
+
+	for _, field := range strings.Split(s, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(field), ":")
+		count, err := strconv.Atoi(value)
+		if !ok || err != nil || count <= 0 || name == "" {
+			return nil, fmt.Errorf("draw rule %s must be category:count, e.g. science:4", field)
+		}
+
+		rule := DrawRule{Count: count}
+		switch {
+		case strings.HasPrefix(name, "#"):
+			rule.Tag = strings.TrimPrefix(name, "#")
+		case name != RandomCategory:
+			rule.Category = name
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// String is the rule in the format of ParseDrawRules
+func (r DrawRule) String() string {
+	switch {
+	case r.Tag != "":
+		return fmt.Sprintf("#%s:%d", r.Tag, r.Count)
+	case r.Category != "":
+		return fmt.Sprintf("%s:%d", r.Category, r.Count)
+	default:
+		return fmt.Sprintf("%s:%d", RandomCategory, r.Count)
+	}
+}
+
+func (r DrawRule) match(question QuestionPayload) bool {
+	switch {
+	case r.Tag != "":
+		return contains(question.tags, r.Tag)
+	case r.Category != "":
+		return question.category == r.Category
+	default:
+		return true
+	}
+}
+
+// Draw pick the question by the rules, the question in exclude is never picked.
+// The random rule is drawn last, so it never take the question needed by the other rule
+func Draw(questions []QuestionPayload, cfg DrawConfig, exclude map[string]bool) ([]QuestionPayload, error) {
+	r := rand.New(rand.NewSource(cfg.Seed))
+
+	available := []QuestionPayload{}
+	for _, question := range questions {
+		if !exclude[question.key()] {
+			available = append(available, question)
+		}
+	}
+
+	rules := []DrawRule{}
+	for _, rule := range cfg.Rules {
+		if rule.Category != "" || rule.Tag != "" {
+			rules = append(rules, rule)
+		}
+	}
+	for _, rule := range cfg.Rules {
+		if rule.Category == "" && rule.Tag == "" {
+			rules = append(rules, rule)
+		}
+	}
+
+	drawn := available
+	if len(rules) > 0 {
+		drawn = []QuestionPayload{}
+		picked := map[int]bool{}
+		for _, rule := range rules {
+			candidates := []int{}
+			for i, question := range available {
+				if !picked[i] && rule.match(question) {
+					candidates = append(candidates, i)
+				}
+			}
+
+			if len(candidates) < rule.Count {
+				return nil, fmt.Errorf("draw rule %s need %d question, only %d available", rule, rule.Count, len(candidates))
+			}
+
+			for _, j := range r.Perm(len(candidates))[:rule.Count] {
+				picked[candidates[j]] = true
+				drawn = append(drawn, available[candidates[j]])
+			}
+		}
+	}
+
+	if len(drawn) == 0 {
+		return nil, errors.New("no question left to draw, reduce the no repeat")
+	}
+
+	result := make([]QuestionPayload, len(drawn))
+	for i, j := range r.Perm(len(drawn)) {
+		result[i] = drawn[j].shuffle(r)
+	}
+
+	return result, nil
+}
+
+// shuffle the options of the multiple-choice question, the answer is moved to the new key
+func (q QuestionPayload) shuffle(r *rand.Rand) QuestionPayload {
+	if len(q.options) == 0 {
+		return q
+	}
+
+	answer := -1
+	for i, key := range q.keys() {
+		if key == q.answer {
+			answer = i
+		}
+	}
+
+	options := make([]string, len(q.options))
+	for i, j := range r.Perm(len(q.options)) {
+		options[i] = q.options[j]
+		if j == answer {
+			q.answer = string(rune('A' + i))
+		}
+	}
+	q.options = options
+
+	return q
+}
+
+// drawSeed is the seed of the draw, zero mean random seed
+func drawSeed(seed int64) int64 {
+	if seed == 0 {
+		return time.Now().UnixNano()
+	}
+
+	return seed
+}
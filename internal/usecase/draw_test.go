@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+)
+
+// drawBank is 3 science, 2 history and 1 question with the math tag
+func drawBank() []QuestionPayload {
+	return []QuestionPayload{
+		{id: "s1", question: "s1", category: "science", options: []string{"H2O", "CO2", "O2"}, answer: "A"},
+		{id: "s2", question: "s2", category: "science", options: []string{"Mars", "Venus", "Earth", "Moon"}, answer: "C"},
+		{id: "s3", question: "s3", category: "science", answer: "Y"},
+		{id: "h1", question: "h1", category: "history", options: []string{"1945", "1939"}, answer: "B"},
+		{id: "h2", question: "h2", category: "history", answer: "N"},
+		{id: "m1", question: "m1", category: "science", tags: []string{"math"}, options: []string{"4", "5", "6"}, answer: "A"},
+	}
+}
+
+// correctOption is the text of the option at the answer key
+func correctOption(q QuestionPayload) string {
+	for i, key := range q.keys() {
+		if key == q.answer && i < len(q.options) {
+			return q.options[i]
+		}
+	}
+
+	return q.answer
+}
+
+func TestDraw(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     DrawConfig
+		exclude map[string]bool
+		// wantCount is the total question drawn from each category
+		wantCount map[string]int
+		wantErr   bool
+	}{
+		{
+			name:      "every question is drawn without the rule",
+			cfg:       DrawConfig{Seed: 7},
+			wantCount: map[string]int{"science": 4, "history": 2},
+		},
+		{
+			name:      "the rule pick from the category and the tag",
+			cfg:       DrawConfig{Seed: 7, Rules: []DrawRule{{Category: "history", Count: 2}, {Tag: "math", Count: 1}}},
+			wantCount: map[string]int{"science": 1, "history": 2},
+		},
+		{
+			name:      "the random rule never take the question of the other rule",
+			cfg:       DrawConfig{Seed: 7, Rules: []DrawRule{{Count: 2}, {Category: "science", Count: 3}}},
+			exclude:   map[string]bool{"s1": true},
+			wantCount: map[string]int{"science": 3, "history": 2},
+		},
+		{
+			name:    "the excluded question is not available",
+			cfg:     DrawConfig{Seed: 7, Rules: []DrawRule{{Category: "history", Count: 2}}},
+			exclude: map[string]bool{"h1": true},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bank := drawBank()
+			got, err := Draw(bank, tt.cfg, tt.exclude)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Draw() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			// the same seed produce the same questions and options order
+			again, _ := Draw(bank, tt.cfg, tt.exclude)
+			if !reflect.DeepEqual(again, got) {
+				t.Errorf("Draw() = %+v, want %+v", again, got)
+			}
+
+			original := map[string]QuestionPayload{}
+			for _, question := range bank {
+				original[question.id] = question
+			}
+
+			count := map[string]int{}
+			for _, question := range got {
+				if tt.exclude[question.id] {
+					t.Errorf("%s is excluded, but drawn", question.id)
+				}
+				count[question.category]++
+
+				// the answer key is moved with the correct option
+				want := original[question.id]
+				if correctOption(question) != correctOption(want) {
+					t.Errorf("%s answer = %s (%s), want %s", question.id, question.answer, correctOption(question), correctOption(want))
+				}
+				if len(question.options) != len(want.options) {
+					t.Errorf("%s options = %v, want %v", question.id, question.options, want.options)
+				}
+			}
+			if !reflect.DeepEqual(count, tt.wantCount) {
+				t.Errorf("count = %v, want %v", count, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestDrawShuffle(t *testing.T) {
+	bank := drawBank()
+
+	// the different seed shuffle the order of the options at least once
+	shuffled := false
+	for seed := int64(1); seed <= 10 && !shuffled; seed++ {
+		got, err := Draw(bank[:1], DrawConfig{Seed: seed}, nil)
+		if err != nil {
+			t.Fatalf("Draw() error = %v", err)
+		}
+		shuffled = !reflect.DeepEqual(got[0].options, bank[0].options)
+	}
+	if !shuffled {
+		t.Error("the options is never shuffled")
+	}
+}
@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SuddenDeathReserve is the total question kept for the sudden death when the total round is not set,
+// the small game keep at most half of the questions
+const SuddenDeathReserve = 3
+
+// eliminationMode is last player standing rule set. Wrong or missing answer
+// eliminate the player, the eliminated player is spectating the game.
+// When the questions run out with more than one player, the sudden death is played
+// with the question which is not asked, the winner is shared when there is no question left
+type eliminationMode struct {
+	mu        sync.RWMutex
+	questions []QuestionPayload
+	// reserve is the question after the total round, it is only asked in the sudden death
+	reserve []QuestionPayload
+	started bool
+	alive   map[string]bool
+	// eliminated is the round when the player is eliminated
+	eliminated map[string]int
+}
+
+func newEliminationMode(questions []QuestionPayload) *eliminationMode {
+	return &eliminationMode{
+		questions:  questions,
+		alive:      map[string]bool{},
+		eliminated: map[string]int{},
+	}
+}
+
+func (m *eliminationMode) Name() string { return EliminationMode }
+
+func (m *eliminationMode) Start(players []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.started = true
+	for _, player := range players {
+		m.alive[player] = true
+	}
+}
+
+func (m *eliminationMode) Next(round int) (QuestionPayload, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if len(m.questions) == 0 || (round > 0 && len(m.alive) <= 1) {
+		return QuestionPayload{}, false
+	}
+
+	if round < len(m.questions) {
+		return m.questions[round], true
+	}
+
+	// the answer of the asked question is revealed, so the sudden death never ask it again
+	if i := round - len(m.questions); i < len(m.reserve) {
+		question := m.reserve[i]
+		question.suddenDeath = true
+		return question, true
+	}
+
+	return QuestionPayload{}, false
+}
+
+func (m *eliminationMode) CanAnswer(player string) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if !m.alive[player] {
+		return errors.New("you are eliminated, you are spectating the game now")
+	}
+
+	return nil
+}
+
+func (m *eliminationMode) EndRound(summary RoundSummary) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// the player who left the game is eliminated
+	present := map[string]bool{}
+	for _, player := range summary.Players {
+		present[player] = true
+	}
+	for player := range m.alive {
+		if !present[player] {
+			m.eliminate(player, summary.Round)
+		}
+	}
+
+	wrong := []string{}
+	for player := range m.alive {
+		answer, ok := summary.Answers[player]
+		if (!ok || answer != summary.Answer) && !summary.Skipped[player] {
+			wrong = append(wrong, player)
+		}
+	}
+	sort.Strings(wrong)
+
+	messages := []string{}
+	switch {
+	case len(wrong) == 0:
+	case len(wrong) == len(m.alive):
+		// nobody is eliminated when all the remaining player is wrong
+		messages = append(messages, "everyone missed the question, nobody is eliminated")
+	default:
+		for _, player := range wrong {
+			m.eliminate(player, summary.Round)
+			messages = append(messages, fmt.Sprintf("player %s is eliminated", player))
+		}
+	}
+
+	switch {
+	case len(m.alive) == 1:
+		for player := range m.alive {
+			messages = append(messages, fmt.Sprintf("player %s is the last player standing", player))
+		}
+	case len(m.alive) > 1 && summary.Round >= len(m.questions)+len(m.reserve):
+		winners := []string{}
+		for player := range m.alive {
+			winners = append(winners, player)
+		}
+		sort.Strings(winners)
+		messages = append(messages, fmt.Sprintf("no question is left for the sudden death, %s share the win", strings.Join(winners, ", ")))
+	}
+
+	return messages
+}
+
+// eliminate must be called when m.mu is locked
+func (m *eliminationMode) eliminate(player string, round int) {
+	delete(m.alive, player)
+	m.eliminated[player] = round
+}
+
+// Rank the last eliminated player is ranked higher, the point is the tiebreaker
+func (m *eliminationMode) Rank(scores []PlayerScore) []PlayerScore {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if !m.started {
+		return scores
+	}
+
+	// the player who joined after the game is started is spectating the game
+	for i := range scores {
+		scores[i].Eliminated = !m.alive[scores[i].Name]
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].Eliminated != scores[j].Eliminated {
+			return !scores[i].Eliminated
+		}
+
+		if m.eliminated[scores[i].Name] != m.eliminated[scores[j].Name] {
+			return m.eliminated[scores[i].Name] > m.eliminated[scores[j].Name]
+		}
+
+		return scores[i].Point > scores[j].Point
+	})
+
+	return scores
+}
@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"time"
+
+	quiz "github.com/elangreza14/grpc-quiz/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// protoEvent is the event which can be sent to the player
+type protoEvent interface {
+	toProto() *quiz.StreamResponse
+}
+
+func (e QuestionEvent) toProto() *quiz.StreamResponse {
+	options := []*quiz.Option{}
+	for _, option := range e.Options {
+		options = append(options, &quiz.Option{
+			Key:  option.Key,
+			Text: option.Text,
+		})
+	}
+
+	return &quiz.StreamResponse{
+		Timestamp: timestamppb.Now(),
+		Event: &quiz.StreamResponse_Question{
+			Question: &quiz.Question{
+				Round:       int32(e.Round),
+				TotalRound:  int32(e.TotalRound),
+				Question:    e.Question,
+				Deadline:    timestamppb.New(e.Deadline),
+				SuddenDeath: e.SuddenDeath,
+				Options:     options,
+				Hints:       int32(e.Hints),
+				Difficulty:  e.Difficulty,
+				TimeLimit:   int32(e.TimeLimit / time.Second),
+				Points:      int32(e.Points),
+			},
+		},
+	}
+}
+
+func (e AnswerProgress) toProto() *quiz.StreamResponse {
+	return &quiz.StreamResponse{
+		Timestamp: timestamppb.Now(),
+		Event: &quiz.StreamResponse_AnswerProgress{
+			AnswerProgress: &quiz.AnswerProgress{
+				Round:       int32(e.Round),
+				TotalAnswer: int32(e.TotalAnswer),
+				TotalPlayer: int32(e.TotalPlayer),
+			},
+		},
+	}
+}
+
+func (e BuzzerEvent) toProto() *quiz.StreamResponse {
+	return &quiz.StreamResponse{
+		Timestamp: timestamppb.Now(),
+		Event: &quiz.StreamResponse_Buzzer{
+			Buzzer: &quiz.Buzzer{
+				Round:     int32(e.Round),
+				Holder:    e.Holder,
+				LockedOut: e.LockedOut,
+			},
+		},
+	}
+}
+
+func (e RoundResult) toProto() *quiz.StreamResponse {
+	distribution := []*quiz.OptionCount{}
+	for i := 0; i < len(e.Distribution); i++ {
+		distribution = append(distribution, &quiz.OptionCount{
+			Option: e.Distribution[i].Option,
+			Total:  int32(e.Distribution[i].Total),
+		})
+	}
+
+	return &quiz.StreamResponse{
+		Timestamp: timestamppb.Now(),
+		Event: &quiz.StreamResponse_RoundResult{
+			RoundResult: &quiz.RoundResult{
+				Round:        int32(e.Round),
+				Question:     e.Question,
+				Answer:       e.Answer,
+				Explanation:  e.Explanation,
+				Distribution: distribution,
+				TotalAnswer:  int32(e.TotalAnswer),
+			},
+		},
+	}
+}
+
+func (e Leaderboard) toProto() *quiz.StreamResponse {
+	return &quiz.StreamResponse{
+		Timestamp: timestamppb.Now(),
+		Event: &quiz.StreamResponse_Leaderboard{
+			Leaderboard: &quiz.Leaderboard{
+				Scores: ProtoScores(e.Scores),
+				Teams:  ProtoTeams(e.Teams),
+				Final:  e.Final,
+			},
+		},
+	}
+}
+
+// Event convert the payload to the event for the player, it is used to play the game without the room.
+// It return false when the payload is not for all the player
+func (s *GameState) Event() (*quiz.StreamResponse, bool) {
+	switch payload := s.payload.(type) {
+	case protoEvent:
+		return payload.toProto(), true
+	case string:
+		return &quiz.StreamResponse{
+			Timestamp: timestamppb.Now(),
+			Event: &quiz.StreamResponse_ServerAnnouncement{
+				ServerAnnouncement: &quiz.Message{
+					Message: payload,
+				},
+			},
+		}, true
+	default:
+		return nil, false
+	}
+}
+
+// ProtoScores is ...
+func ProtoScores(scores []PlayerScore) []*quiz.PlayerScore {
+	res := []*quiz.PlayerScore{}
+	for i := 0; i < len(scores); i++ {
+		res = append(res, &quiz.PlayerScore{
+			Player:     scores[i].Name,
+			Point:      int32(scores[i].Point),
+			Eliminated: scores[i].Eliminated,
+			Bot:        scores[i].Bot,
+		})
+	}
+
+	return res
+}
+
+// ProtoTeams is ...
+func ProtoTeams(teams []TeamScore) []*quiz.TeamScore {
+	res := []*quiz.TeamScore{}
+	for i := 0; i < len(teams); i++ {
+		res = append(res, &quiz.TeamScore{
+			Team:    teams[i].Name,
+			Point:   teams[i].Point,
+			Members: teams[i].Members,
+		})
+	}
+
+	return res
+}
+
+// ProtoLifelines is ...
+func ProtoLifelines(usages []LifelineUsage) []*quiz.LifelineUsage {
+	res := []*quiz.LifelineUsage{}
+	for i := 0; i < len(usages); i++ {
+		res = append(res, &quiz.LifelineUsage{
+			Player:   usages[i].Player,
+			Lifeline: usages[i].Lifeline,
+			Round:    int32(usages[i].Round),
+		})
+	}
+
+	return res
+}
+
+func (e RoundPaused) toProto() *quiz.StreamResponse {
+	return &quiz.StreamResponse{
+		Timestamp: timestamppb.Now(),
+		Event: &quiz.StreamResponse_Paused{
+			Paused: &quiz.Paused{
+				Round:     int32(e.Round),
+				Remaining: durationpb.New(e.Remaining),
+			},
+		},
+	}
+}
+
+func (e RoundResumed) toProto() *quiz.StreamResponse {
+	return &quiz.StreamResponse{
+		Timestamp: timestamppb.Now(),
+		Event: &quiz.StreamResponse_Resumed{
+			Resumed: &quiz.Resumed{
+				Round:    int32(e.Round),
+				Deadline: timestamppb.New(e.Deadline),
+				Extended: durationpb.New(e.Extended),
+			},
+		},
+	}
+}
+
+func (e RoundReview) toProto() *quiz.StreamResponse {
+	return &quiz.StreamResponse{
+		Timestamp: timestamppb.Now(),
+		Event: &quiz.StreamResponse_Review{
+			Review: &quiz.Review{
+				Round:      int32(e.Round),
+				TotalRound: int32(e.TotalRound),
+			},
+		},
+	}
+}
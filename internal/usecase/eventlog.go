@@ -0,0 +1,282 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// the kind of the line in the event log
+const (
+	logRoom    = "room"
+	logEvent   = "event"
+	logState   = "state"
+	logControl = "control"
+	logRemove  = "remove"
+	logResult  = "result"
+)
+
+type (
+	// EventLog is the append-only log of the room, one json per line.
+	// It record every event of the queue, every state of the game, the action of the host and the final scores
+	EventLog struct {
+		mu     sync.Mutex
+		enc    *json.Encoder
+		closer io.Closer
+		// start is the time of the room in the header, the time of the line is measured from it by the clock of the room
+		clock Clock
+		start time.Time
+		// states is kept by the log of the replay, it is compared to the recorded log
+		states []LogEntry
+		keep   bool
+	}
+
+	// LogEntry is the line of the event log
+	LogEntry struct {
+		// At is the time since the room is written to the log, it is measured by the clock of the room
+		At   time.Duration `json:"at"`
+		Kind string        `json:"kind"`
+		// Type is the event type, the payload type of the state or the action of the host
+		Type  string `json:"type,omitempty"`
+		State *State `json:"state,omitempty"`
+		// Bot and Team is set when the player is inserted, the team is joined before the player is inserted
+		Bot     bool            `json:"bot,omitempty"`
+		Team    string          `json:"team,omitempty"`
+		Payload json.RawMessage `json:"payload,omitempty"`
+	}
+
+	// roomHeader is the first line of the log, the room is created again from it by the replay
+	roomHeader struct {
+		ID        string
+		StartedAt time.Time
+		Config    RoomConfig
+		// Questions is the question of the game after it is drawn, nil mean the default questions
+		Questions []Question
+	}
+
+	controlPayload struct {
+		Action RoundControl
+		Extend time.Duration
+	}
+)
+
+var eventNames = map[eventType]string{
+	InsertPlayer:      "insert_player",
+	Broadcast:         "broadcast",
+	BroadcastPersonal: "broadcast_personal",
+	StartGame:         "start_game",
+	SubmitAnswer:      "submit_answer",
+	TeamChat:          "team_chat",
+	Buzz:              "buzz",
+	CloseRoom:         "close_room",
+	UseLifeline:       "use_lifeline",
+	RequestHint:       "request_hint",
+	ReloadQuestions:   "reload_questions",
+}
+
+// OpenEventLog create the log file of the room in the directory, e.g. room-1-20240101-090000.jsonl
+func OpenEventLog(dir, room string) (*EventLog, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.jsonl", room, time.Now().Format("20060102-150405")))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EventLog{
+		enc:    json.NewEncoder(file),
+		closer: file,
+		clock:  RealClock,
+		start:  time.Now(),
+	}, nil
+}
+
+// newMemoryLog keep the states in memory, the time is measured by the clock
+func newMemoryLog(clock Clock) *EventLog {
+	return &EventLog{
+		keep:  true,
+		clock: clock,
+		start: clock.Now(),
+	}
+}
+
+// Path is the file of the log
+func (l *EventLog) Path() string {
+	if file, ok := l.closer.(*os.File); ok {
+		return file.Name()
+	}
+
+	return ""
+}
+
+// Close the log file
+func (l *EventLog) Close() error {
+	if l == nil || l.closer == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// the player can still leave the finished room, the line after the close is dropped
+	l.enc = nil
+	return l.closer.Close()
+}
+
+// write is no operation for the nil log, so the room without log doesn't check it
+func (l *EventLog) write(entry LogEntry, payload any) {
+	if l == nil {
+		return
+	}
+
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			fmt.Printf("event log: %v\n", err)
+			return
+		}
+		entry.Payload = data
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry.At = l.clock.Now().Sub(l.start)
+	if l.keep && entry.Kind == logState {
+		l.states = append(l.states, entry)
+	}
+
+	if l.enc != nil {
+		if err := l.enc.Encode(entry); err != nil {
+			fmt.Printf("event log: %v\n", err)
+		}
+	}
+}
+
+// room restart the log with the clock of the room, so the replay clock start at the same time as the recorded offsets
+func (l *EventLog) room(r *Room) {
+	l.mu.Lock()
+	switch game := r.Game.(type) {
+	case *GamePlay:
+		l.clock = game.clock
+	case *AsyncGame:
+		l.clock = game.clock
+	}
+	l.start = l.clock.Now()
+	start := l.start
+	l.mu.Unlock()
+
+	header := roomHeader{
+		ID:        r.ID,
+		StartedAt: start,
+		Config:    r.source,
+	}
+	header.Config.Questions = nil
+	header.Config.Draw = nil
+	header.Config.Bots = BotConfig{}
+	header.Config.Clock = nil
+
+	// the question and the seed of the game is recorded after it is drawn
+	if game, ok := r.Game.(*GamePlay); ok {
+		header.Config.Adaptive = game.config.Adaptive
+		header.Config.LifelineSeed = game.config.LifelineSeed
+		header.Questions = toQuestions(game.config.Questions)
+	}
+
+	l.write(LogEntry{Kind: logRoom}, header)
+}
+
+func (l *EventLog) event(r *Room, evt *Event) {
+	entry := LogEntry{Kind: logEvent, Type: eventNames[evt.EventType]}
+
+	payload := evt.Payload
+	switch p := evt.Payload.(type) {
+	case []QuestionPayload:
+		payload = toQuestions(p)
+	case string:
+		if evt.EventType == InsertPlayer {
+			entry.Bot = r.IsBot(p)
+			if r.Teams != nil {
+				entry.Team, _ = r.Teams.TeamOf(p)
+			}
+		}
+	}
+
+	l.write(entry, payload)
+}
+
+func (l *EventLog) state(state *GameState) {
+	entry := LogEntry{Kind: logState, State: &state.State}
+	if state.payload != nil {
+		entry.Type = reflect.TypeOf(state.payload).Name()
+	}
+
+	l.write(entry, state.payload)
+}
+
+func (l *EventLog) control(action RoundControl, extend time.Duration) {
+	l.write(LogEntry{Kind: logControl, Type: action.String()}, controlPayload{Action: action, Extend: extend})
+}
+
+func (l *EventLog) remove(player string) {
+	l.write(LogEntry{Kind: logRemove}, player)
+}
+
+func (l *EventLog) result(scores []PlayerScore) {
+	l.write(LogEntry{Kind: logResult}, scores)
+}
+
+// stateAt is the state kept in memory, false when the state is not emitted yet
+func (l *EventLog) stateAt(i int) (LogEntry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if i >= len(l.states) {
+		return LogEntry{}, false
+	}
+
+	return l.states[i], true
+}
+
+// toQuestion is the reverse of the payload, it is used to write the question to the log
+func (q QuestionPayload) toQuestion() Question {
+	question := Question{
+		ID:          q.id,
+		Question:    q.question,
+		Options:     q.options,
+		Answer:      q.answer,
+		Explanation: q.explanation,
+		Hints:       q.hints,
+		Category:    q.category,
+		Tags:        q.tags,
+		TimeLimit:   int(q.timeLimit / time.Second),
+		Weight:      q.weight,
+	}
+
+	if q.difficulty != 0 {
+		question.Difficulty = DifficultyName(q.difficulty)
+	}
+
+	return question
+}
+
+func toQuestions(payloads []QuestionPayload) []Question {
+	if payloads == nil {
+		return nil
+	}
+
+	questions := []Question{}
+	for _, payload := range payloads {
+		questions = append(questions, payload.toQuestion())
+	}
+
+	return questions
+}
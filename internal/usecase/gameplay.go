@@ -2,8 +2,11 @@
 package usecase
 
 import (
+	"errors"
 	"fmt"
+	"math/rand"
 	"sort"
+	"sync"
 	"time"
 )
 
@@ -27,38 +30,174 @@ type (
 
 	// GamePlay is ...
 	GamePlay struct {
-		players        map[string]int
+		mu      sync.RWMutex
+		players map[string]int
+		teams   *Teams
+		// modeMu only guard the mode, the mode is replaced by SetQuestions
+		modeMu         sync.RWMutex
+		mode           GameMode
 		state          State
 		internalStream chan *internalAction
 		externalStream chan *GameState
 		questionStream chan *QuestionPayload
-		stopStream     chan bool
-		questions      []QuestionPayload
-		timePerRound   time.Duration
-		expected       QuestionPayload
-		round          int
+		// stopStream is closed by Close, every goroutine and the timer of the game is stopped
+		stopStream   chan bool
+		stopOnce     sync.Once
+		questions    []QuestionPayload
+		timePerRound time.Duration
+		expected     QuestionPayload
+		round        int
+		// deadline is the end of the round, it is later than the question deadline when a player use extra time
+		deadline  time.Time
+		lifelines *lifelines
+		hints     HintConfig
+		rand      *rand.Rand
+		// totalRound is less than the questions when the question is picked by the adaptive difficulty
+		totalRound int
+		adaptive   *AdaptiveConfig
+		config     GameConfig
+		// paused stop the countdown of the round since pausedAt
+		paused   bool
+		pausedAt time.Time
+		// review hold the game after each round until the host start the next round
+		review    bool
+		reviewing bool
+		clock     Clock
+		// answers is the answer of every round, it is recorded when the round is ended
+		answers []AnswerRecord
+	}
+
+	// GameConfig is ...
+	GameConfig struct {
+		Mode  string
+		Teams *Teams
+		// TotalRound limit the question, zero mean all the question
+		TotalRound int
+		// Lifelines is the usage count of each lifeline for every player, nil mean the default count
+		Lifelines map[string]int
+		Hints     HintConfig
+		// Adaptive pick the question by the accuracy of the room, nil mean the question is asked in order
+		Adaptive *AdaptiveConfig
+		// Questions is the question of the game, nil mean the default question
+		Questions []QuestionPayload
+		// Review hold the game after each round until the host start the next round
+		Review bool
+		// Clock measure the round and the deadline of the answer, nil mean the wall clock
+		Clock Clock
+		// LifelineSeed is the seed of the 50/50 lifeline, zero is random
+		LifelineSeed int64
 	}
 
 	// SubmitAnswerPayload ...
 	SubmitAnswerPayload struct {
-		Name   string
-		Answer bool
+		Name string
+		// Answer is the key of the option, e.g. Y or N for the true or false question
+		Answer string
 	}
 
 	// QuestionPayload ...
 	QuestionPayload struct {
-		question      string
-		answer        bool
+		// id is optional, the question text is used to identify the question without id
+		id       string
+		question string
+		// options is empty for the true or false question
+		options     []string
+		answer      string
+		explanation string
+		// hints is ordered from the vague to the specific hint
+		hints      []string
+		difficulty int
+		category   string
+		tags       []string
+		// timeLimit override the time per round, weight multiply the point of the correct answer
+		timeLimit     time.Duration
+		weight        int
 		block         chan bool
+		extend        chan bool
 		playerRetries map[string]int
+		playerAnswers map[string]string
+		round         int
+		// started is moved by the pause, the hint is unlocked from the start of the round
+		started     time.Time
+		deadline    time.Time
+		suddenDeath bool
+		// the lifeline used by the player in the round
+		skipped   map[string]bool
+		doubled   map[string]bool
+		deadlines map[string]time.Time
+		// hintsUsed is the hint requested by the player, revealed is the hint revealed to every player
+		hintsUsed map[string]int
+		revealed  int
+		closed    bool
+		// latencies is the time of the first answer of the player since the start of the round
+		latencies map[string]time.Duration
+	}
+
+	// QuestionEvent is emitted when the round is started
+	QuestionEvent struct {
+		Round       int
+		TotalRound  int
+		Question    string
+		Options     []Option
+		Deadline    time.Time
+		SuddenDeath bool
+		// Hints is total hint the player can request
+		Hints      int
+		Difficulty string
+		// TimeLimit is the time to answer the question, Points is the point of the correct answer
+		TimeLimit time.Duration
+		Points    int
+	}
+
+	// AnswerProgress is emitted when a player answer the question for the first time
+	AnswerProgress struct {
+		Round       int
+		TotalAnswer int
+		TotalPlayer int
+	}
+
+	// OptionCount is total player who choose the option
+	OptionCount struct {
+		Option string
+		Total  int
+	}
+
+	// RoundResult is emitted when the round is ended
+	RoundResult struct {
+		Round        int
+		Question     string
+		Answer       string
+		Explanation  string
+		Distribution []OptionCount
+		TotalAnswer  int
+	}
+
+	// Leaderboard is emitted after each round and when the game is finished
+	Leaderboard struct {
+		Scores []PlayerScore
+		Teams  []TeamScore
+		Final  bool
 	}
 )
 
+// DefaultTimePerRound is ...
+const DefaultTimePerRound = 10 * time.Second
+
 const (
 	start action = iota
 	setQuestion
 	answerQuestion
-	finish
+	endRound
+	buzz
+	resolveBuzz
+	timeoutBuzz
+	join
+	timeoutQuestion
+	closeQuiz
+	useLifeline
+	requestHint
+	revealHint
+	controlRound
 
 	// Waiting is
 	Waiting State = iota
@@ -69,174 +208,925 @@ const (
 )
 
 // NewGamePlay is ...
-func NewGamePlay() *GamePlay {
-	Questions := []QuestionPayload{
-		{
-			question:      "1 + 1 = 2",
-			answer:        true,
-			block:         make(chan bool),
-			playerRetries: map[string]int{},
-		},
-		{
-			question:      "1 - 1 = -1",
-			answer:        false,
-			block:         make(chan bool),
-			playerRetries: map[string]int{},
-		},
-		{
-			question:      "1 * 0 = 0",
-			answer:        true,
-			block:         make(chan bool),
-			playerRetries: map[string]int{},
-		},
+func NewGamePlay(cfg GameConfig) (*GamePlay, error) {
+	if cfg.Adaptive != nil && cfg.Adaptive.Seed == 0 {
+		adaptive := *cfg.Adaptive
+		adaptive.Seed = time.Now().UnixNano()
+		cfg.Adaptive = &adaptive
+	}
+
+	questions := cfg.Questions
+	if questions == nil {
+		questions = defaultQuestions()
+	}
+
+	mode, questions, totalRound, err := newMode(cfg, questions)
+	if err != nil {
+		return nil, err
+	}
+
+	hints, err := newHintConfig(cfg.Hints)
+	if err != nil {
+		return nil, err
+	}
+
+	clock := cfg.Clock
+	if clock == nil {
+		clock = RealClock
+	}
+
+	if cfg.LifelineSeed == 0 {
+		cfg.LifelineSeed = time.Now().UnixNano()
 	}
 
 	g := &GamePlay{
 		players:        map[string]int{},
+		teams:          cfg.Teams,
+		mode:           mode,
 		state:          Waiting,
 		internalStream: make(chan *internalAction),
-		externalStream: make(chan *GameState),
-		questionStream: make(chan *QuestionPayload, len(Questions)),
+		externalStream: make(chan *GameState, 100),
+		questionStream: make(chan *QuestionPayload, 1),
 		stopStream:     make(chan bool),
-		questions:      Questions,
-		timePerRound:   10 * time.Second,
+		questions:      questions,
+		timePerRound:   DefaultTimePerRound,
+		lifelines:      newLifelines(cfg.Lifelines),
+		hints:          hints,
+		totalRound:     totalRound,
+		adaptive:       cfg.Adaptive,
+		config:         cfg,
+		review:         cfg.Review,
+		clock:          clock,
+		rand:           rand.New(rand.NewSource(cfg.LifelineSeed)),
 	}
 
 	go g.listenInternalStream()
 	go g.listenQuestion()
 
-	return g
+	return g, nil
+}
+
+// newMode create the rule set of the questions, the questions is limited by the total round
+func newMode(cfg GameConfig, questions []QuestionPayload) (GameMode, []QuestionPayload, int, error) {
+	if len(questions) == 0 {
+		return nil, nil, 0, errors.New("the game has no question")
+	}
+
+	totalRound := len(questions)
+	if cfg.TotalRound > 0 && cfg.TotalRound < totalRound {
+		totalRound = cfg.TotalRound
+	}
+
+	// the adaptive difficulty pick from every question
+	if cfg.Adaptive != nil {
+		if cfg.Mode != ClassicMode && cfg.Mode != "" {
+			return nil, nil, 0, fmt.Errorf("adaptive difficulty is only available in %s and %s mode", ClassicMode, AsyncMode)
+		}
+
+		return newAdaptiveMode(questions, totalRound, *cfg.Adaptive), questions, totalRound, nil
+	}
+
+	// the elimination without the total round keep the last questions for the sudden death, at most half of the questions
+	if cfg.Mode == EliminationMode && cfg.TotalRound <= 0 {
+		reserve := SuddenDeathReserve
+		if reserve > len(questions)/2 {
+			reserve = len(questions) / 2
+		}
+		totalRound -= reserve
+	}
+
+	mode, err := NewGameMode(cfg.Mode, questions[:totalRound])
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	// the question which is not asked is kept for the sudden death
+	if m, ok := mode.(*eliminationMode); ok {
+		m.reserve = questions[totalRound:]
+	}
+
+	return mode, questions, totalRound, nil
+}
+
+// SetQuestions replace the questions before the game is started, the started game keep its questions
+func (g *GamePlay) SetQuestions(questions []QuestionPayload) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.state != Waiting {
+		return errors.New("the game is already started")
+	}
+
+	mode, questions, totalRound, err := newMode(g.config, questions)
+	if err != nil {
+		return err
+	}
+
+	g.modeMu.Lock()
+	g.mode = mode
+	g.modeMu.Unlock()
+	g.questions = questions
+	g.totalRound = totalRound
+
+	return nil
 }
 
+// gameMode is replaced by SetQuestions before the game is started
+func (g *GamePlay) gameMode() GameMode {
+	g.modeMu.RLock()
+	defer g.modeMu.RUnlock()
+
+	return g.mode
+}
+
+func defaultQuestions() []QuestionPayload {
+	return []QuestionPayload{
+		{
+			question:    "1 + 1 = 2",
+			answer:      "Y",
+			difficulty:  Easy,
+			category:    "math",
+			tags:        []string{"addition"},
+			explanation: "adding one to one is two",
+			hints:       []string{"count your fingers"},
+		},
+		{
+			question:    "1 - 1 = -1",
+			answer:      "N",
+			difficulty:  Medium,
+			category:    "math",
+			tags:        []string{"subtraction"},
+			explanation: "subtracting a number from itself is always zero",
+			hints:       []string{"what is left when you take away everything?", "the answer is not negative"},
+		},
+		{
+			question:    "1 * 0 = 0",
+			answer:      "Y",
+			difficulty:  Easy,
+			category:    "math",
+			tags:        []string{"multiplication"},
+			explanation: "any number multiplied by zero is zero",
+		},
+		{
+			question:    "12 / 4 = ?",
+			options:     []string{"2", "3", "4", "6"},
+			answer:      "B",
+			difficulty:  Hard,
+			category:    "math",
+			tags:        []string{"division"},
+			explanation: "4 times 3 is 12",
+			hints:       []string{"it is an odd number", "4 + 4 + 4 = 12"},
+		},
+	}
+}
+
+// setAction is dropped when the game is closed, e.g. the timer which is fired after the room is done
 func (g *GamePlay) setAction(action action, payload any) {
-	g.internalStream <- &internalAction{
+	select {
+	case g.internalStream <- &internalAction{
 		action:  action,
 		payload: payload,
+	}:
+	case <-g.stopStream:
 	}
 }
 
+// Close stop the goroutine of the game, the result of the game is kept
+func (g *GamePlay) Close() {
+	g.stopOnce.Do(func() {
+		close(g.stopStream)
+	})
+}
+
 func (g *GamePlay) listenInternalStream() {
-	for res := range g.internalStream {
+	for {
+		var res *internalAction
+		select {
+		case <-g.stopStream:
+			return
+		case res = <-g.internalStream:
+		}
+
 		switch res.action {
 		case start:
+			g.mu.Lock()
+			g.state = OnProgress
+			players := []string{}
+			for name := range g.players {
+				players = append(players, name)
+			}
+			g.mu.Unlock()
+
+			g.gameMode().Start(players)
 			g.externalStream <- &GameState{
 				State: OnProgress,
 			}
-			g.state = OnProgress
-			for i := 0; i < len(g.questions); i++ {
-				q := g.questions[i]
-				g.questionStream <- &q
 
+			if g.adaptive != nil {
+				g.externalStream <- &GameState{
+					State:   OnProgress,
+					payload: fmt.Sprintf("the question is picked by the accuracy of the room, seed %d", g.adaptive.Seed),
+				}
 			}
+			g.nextRound(0)
 		case setQuestion:
+			// the host read the round from another goroutine in Control
+			g.mu.Lock()
 			g.expected = res.payload.(QuestionPayload)
+			question := g.expected
+			totalRound := g.roundTotal(question.round + 1)
+			g.mu.Unlock()
+
+			if b, ok := g.gameMode().(buzzer); ok {
+				b.StartRound(question.round)
+			}
 			g.externalStream <- &GameState{
-				State:   OnProgress,
-				payload: fmt.Sprintf("round %d: %s", g.round+1, g.expected.question),
+				State: OnProgress,
+				payload: QuestionEvent{
+					Round:       question.round + 1,
+					TotalRound:  totalRound,
+					Question:    question.question,
+					Options:     question.choices(),
+					Deadline:    question.deadline,
+					SuddenDeath: question.suddenDeath,
+					Hints:       g.hintTotal(question),
+					Difficulty:  DifficultyName(question.level()),
+					TimeLimit:   question.timeOf(g.timePerRound),
+					Points:      g.hints.point(question.weightOf(), 0),
+				},
 			}
+			g.scheduleHints(question)
 		case answerQuestion:
 			payload := res.payload.(SubmitAnswerPayload)
-			if payload.Answer == g.expected.answer {
-				g.players[payload.Name]++
+			if err := g.expected.validAnswer(payload.Answer); err != nil {
+				g.sendPersonal(payload.Name, err.Error())
+				continue
+			}
+
+			g.mu.Lock()
+			// the round skipped by the host is closed before its deadline
+			if g.expected.closed {
+				g.mu.Unlock()
+				g.sendPersonal(payload.Name, "the round is over, your answer is not counted")
+				continue
+			}
+
+			if g.paused {
+				g.mu.Unlock()
+				g.sendPersonal(payload.Name, ErrPaused.Error())
+				continue
 			}
 
-			// calculate the retry
-			if _, ok := g.questions[g.round].playerRetries[payload.Name]; ok {
-				g.questions[g.round].playerRetries[payload.Name]++
+			if g.clock.Now().After(g.deadlineOf(payload.Name)) {
+				g.mu.Unlock()
+				g.sendPersonal(payload.Name, "time is up, your answer is not counted")
+				continue
+			}
+
+			// only the first answer is counted, the rest is retry
+			_, retry := g.expected.playerRetries[payload.Name]
+			if retry {
+				g.expected.playerRetries[payload.Name]++
 			} else {
-				g.questions[g.round].playerRetries[payload.Name] = 0
+				g.expected.playerRetries[payload.Name] = 0
+				g.expected.playerAnswers[payload.Name] = payload.Answer
+				g.expected.latencies[payload.Name] = g.clock.Now().Sub(g.expected.started)
+				if payload.Answer == g.expected.answer {
+					g.addPoint(payload.Name)
+				}
+			}
+
+			correct := payload.Answer == g.expected.answer
+			allAnswered := g.allAnswered()
+			progress := g.answerProgress()
+			g.mu.Unlock()
+
+			if !retry {
+				g.externalStream <- &GameState{
+					State:   OnProgress,
+					payload: progress,
+				}
+			}
+
+			// in buzzer mode the round is ended by the answer of the buzzer holder
+			if b, ok := g.gameMode().(buzzer); ok {
+				allAnswered = false
+				if event, ended, ok := b.Answer(payload.Name, correct); ok && !retry {
+					allAnswered = g.buzzerAnswered(event, ended)
+				}
+			}
+
+			if allAnswered {
+				g.endRoundEarly()
+			}
+		case useLifeline:
+			g.useLifeline(res.payload.(LifelinePayload))
+		case requestHint:
+			g.requestHint(res.payload.(string))
+		case revealHint:
+			g.revealHint(res.payload.(hintReveal))
+		case controlRound:
+			g.controlRound(res.payload.(roundControl))
+		case buzz:
+			payload := res.payload.(BuzzPayload)
+			b := g.gameMode().(buzzer)
+			opened, err := b.Buzz(payload)
+			if err != nil {
+				g.sendPersonal(payload.Name, err.Error())
+				continue
+			}
+
+			// the buzz received in the tie window is ordered by the received time
+			if opened {
+				round := g.expected.round
+				g.clock.AfterFunc(BuzzerTieWindow, func() {
+					g.setAction(resolveBuzz, round)
+				})
+			}
+		case resolveBuzz:
+			event, ok := g.gameMode().(buzzer).Resolve(res.payload.(int))
+			if !ok {
+				continue
+			}
+
+			g.externalStream <- &GameState{
+				State:   OnProgress,
+				payload: event,
+			}
+
+			round := g.expected.round
+			g.clock.AfterFunc(BuzzerAnswerTime, func() {
+				g.setAction(timeoutBuzz, BuzzerEvent{Round: round, Holder: event.Holder})
+			})
+		case timeoutBuzz:
+			payload := res.payload.(BuzzerEvent)
+			event, ended, ok := g.gameMode().(buzzer).Timeout(payload.Round, payload.Holder)
+			if !ok {
+				continue
+			}
+
+			g.externalStream <- &GameState{
+				State:   OnProgress,
+				payload: fmt.Sprintf("player %s ran out of time", payload.Holder),
 			}
 
-			if len(g.questions[g.round].playerRetries) == len(g.players) {
-				g.expected.block <- true
+			if g.buzzerAnswered(event, ended) {
+				g.endRoundEarly()
 			}
+		case endRound:
+			g.mu.Lock()
+			g.expected.closed = true
+			g.mu.Unlock()
 
-		case finish:
 			g.externalStream <- &GameState{
-				State: Done,
+				State:   OnProgress,
+				payload: g.roundResult(),
+			}
+
+			// the answer is recorded before the player is eliminated by the round
+			g.recordAnswers()
+
+			for _, message := range g.gameMode().EndRound(g.roundSummary()) {
+				g.externalStream <- &GameState{
+					State:   OnProgress,
+					payload: message,
+				}
+			}
+
+			g.externalStream <- &GameState{
+				State: OnProgress,
+				payload: Leaderboard{
+					Scores: g.Scores(),
+					Teams:  g.TeamScores(),
+				},
 			}
-			g.state = Done
+
+			if g.startReview() {
+				continue
+			}
+
+			g.nextRound(g.expected.round + 1)
 		}
 	}
 }
 
+// buzzerAnswered open the buzzer again when the round is not ended
+func (g *GamePlay) buzzerAnswered(event BuzzerEvent, ended bool) bool {
+	if !ended {
+		g.externalStream <- &GameState{
+			State:   OnProgress,
+			payload: event,
+		}
+	}
+
+	return ended
+}
+
+func (g *GamePlay) endRoundEarly() {
+	g.mu.RLock()
+	block := g.expected.block
+	g.mu.RUnlock()
+
+	select {
+	case block <- true:
+	default:
+	}
+}
+
+// nextRound ask the game mode for the question of the round,
+// the game is finished when there is no more question
+func (g *GamePlay) nextRound(round int) {
+	question, ok := g.gameMode().Next(round)
+	if !ok {
+		g.finish()
+		return
+	}
+
+	question.round = round
+	question.block = make(chan bool, 1)
+	question.extend = make(chan bool, 1)
+	question.playerRetries = map[string]int{}
+	question.playerAnswers = map[string]string{}
+	question.skipped = map[string]bool{}
+	question.doubled = map[string]bool{}
+	question.deadlines = map[string]time.Time{}
+	question.hintsUsed = map[string]int{}
+	question.latencies = map[string]time.Duration{}
+
+	g.mu.Lock()
+	g.round = round
+	g.mu.Unlock()
+
+	select {
+	case g.questionStream <- &question:
+	case <-g.stopStream:
+	}
+}
+
+func (g *GamePlay) finish() {
+	g.mu.Lock()
+	g.state = Done
+	g.mu.Unlock()
+
+	close(g.questionStream)
+	g.externalStream <- &GameState{
+		State: Done,
+	}
+}
+
+// roundSummary sort the players, so the mode which depend on the order of the answer is reproducible
+func (g *GamePlay) roundSummary() RoundSummary {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	players := []string{}
+	for name := range g.players {
+		players = append(players, name)
+	}
+	sort.Strings(players)
+
+	answers := map[string]string{}
+	for name, answer := range g.expected.playerAnswers {
+		answers[name] = answer
+	}
+
+	skipped := map[string]bool{}
+	for name := range g.expected.skipped {
+		skipped[name] = true
+	}
+
+	return RoundSummary{
+		Round:   g.expected.round + 1,
+		Answer:  g.expected.answer,
+		Players: players,
+		Answers: answers,
+		Skipped: skipped,
+	}
+}
+
+// recordAnswers keep the answer of every player who can answer the round, the player who doesn't answer is recorded too
+func (g *GamePlay) recordAnswers() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	_, buzzerMode := g.gameMode().(buzzer)
+
+	names := []string{}
+	for name := range g.players {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		answer, answered := g.expected.playerAnswers[name]
+		// in buzzer mode every player can buzz in
+		if !answered && !buzzerMode && !g.canAnswer(name) {
+			continue
+		}
+
+		g.answers = append(g.answers, AnswerRecord{
+			Round:         g.expected.round + 1,
+			Question:      g.expected.question,
+			CorrectAnswer: g.expected.answer,
+			Player:        name,
+			Answer:        answer,
+			Correct:       answered && answer == g.expected.answer,
+			Latency:       g.expected.latencies[name],
+		})
+	}
+}
+
+// allAnswered must be called when g.mu is locked
+func (g *GamePlay) allAnswered() bool {
+	for name := range g.players {
+		if !g.canAnswer(name) {
+			continue
+		}
+
+		// the player who run out of time is not waited
+		_, ok := g.expected.playerRetries[name]
+		if !ok && g.clock.Now().Before(g.deadlineOf(name)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// deadlineOf is the deadline of the player, it must be called when g.mu is locked
+func (g *GamePlay) deadlineOf(name string) time.Time {
+	if deadline, ok := g.expected.deadlines[name]; ok {
+		return deadline
+	}
+
+	return g.expected.deadline
+}
+
+// addPoint must be called when g.mu is locked, the point is reduced by the hint and doubled by the lifeline
+func (g *GamePlay) addPoint(name string) {
+	point := g.hints.point(g.expected.weightOf(), g.hintsOf(name))
+	if g.expected.doubled[name] {
+		point *= 2
+	}
+
+	g.players[name] += point
+	if g.teams != nil && g.teams.Policy == CaptainAnswer {
+		g.teams.AddPoint(name, point)
+	}
+}
+
+// answerProgress must be called when g.mu is locked, the skipped player is counted as answered.
+// The answer of the player who left is not counted, so the total answer is never more than the total player
+func (g *GamePlay) answerProgress() AnswerProgress {
+	answered := 0
+	for name := range g.expected.playerRetries {
+		if _, ok := g.players[name]; ok {
+			answered++
+		}
+	}
+
+	return AnswerProgress{
+		Round:       g.expected.round + 1,
+		TotalAnswer: answered,
+		TotalPlayer: g.totalAnswerer(),
+	}
+}
+
+func (g *GamePlay) sendPersonal(name, message string) {
+	g.externalStream <- &GameState{
+		State: OnProgress,
+		payload: BroadcastPersonalPayload{
+			Name:    name,
+			Message: message,
+		},
+	}
+}
+
+// totalAnswerer must be called when g.mu is locked
+func (g *GamePlay) totalAnswerer() int {
+	// in buzzer mode every player can buzz in
+	if _, ok := g.gameMode().(buzzer); ok {
+		return len(g.players)
+	}
+
+	total := 0
+	for name := range g.players {
+		if g.canAnswer(name) {
+			total++
+		}
+	}
+
+	return total
+}
+
+func (g *GamePlay) canAnswer(name string) bool {
+	return g.CanAnswer(name) == nil
+}
+
+// CanAnswer return the reason when the player can't answer the question
+func (g *GamePlay) CanAnswer(name string) error {
+	if err := g.gameMode().CanAnswer(name); err != nil {
+		return err
+	}
+
+	// in captain policy only the captain answer for the team
+	if g.teams != nil && !g.teams.CanAnswer(name) {
+		team, _ := g.teams.TeamOf(name)
+		return fmt.Errorf("only the captain of team %s can answer. use /t to chat with your team", team)
+	}
+
+	return nil
+}
+
+func (g *GamePlay) roundResult() RoundResult {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	distribution := []OptionCount{}
+	for _, key := range g.expected.keys() {
+		distribution = append(distribution, OptionCount{Option: key})
+	}
+	for _, answer := range g.expected.playerAnswers {
+		for i := range distribution {
+			if distribution[i].Option == answer {
+				distribution[i].Total++
+			}
+		}
+	}
+
+	return RoundResult{
+		Round:        g.expected.round + 1,
+		Question:     g.expected.question,
+		Answer:       g.expected.answer,
+		Explanation:  g.expected.explanation,
+		Distribution: distribution,
+		TotalAnswer:  len(g.expected.playerAnswers),
+	}
+}
+
+// questionOf return the question with the answer, it is used by the bot
+func (g *GamePlay) questionOf(question string) (QuestionPayload, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	for _, q := range g.questions {
+		if q.question == question {
+			return q, true
+		}
+	}
+
+	return QuestionPayload{}, false
+}
+
 // Start ...
 func (g *GamePlay) Start() {
 	g.setAction(start, nil)
 }
 
 func (g *GamePlay) listenQuestion() {
-	for i := 0; i < len(g.questions); i++ {
-		g.round = i
-		question := <-g.questionStream
+	for {
+		var question *QuestionPayload
+		select {
+		case <-g.stopStream:
+			return
+		case q, ok := <-g.questionStream:
+			if !ok {
+				return
+			}
+			question = q
+		}
+
+		limit := question.timeOf(g.timePerRound)
+		question.started = g.clock.Now()
+		question.deadline = question.started.Add(limit)
+
+		g.mu.Lock()
+		g.deadline = question.deadline
+		g.paused = false
+		g.mu.Unlock()
+
+		// the timer is started before the question is sent, so the round can't end before the timer exists
+		timer := g.clock.NewTimer(limit)
+		timeout := timer.C()
 
 		g.setAction(setQuestion, *question)
 
-		timeout := time.After(g.timePerRound)
+	round:
+		for {
+			select {
+			case <-g.stopStream:
+				timer.Stop()
+				return
+			case <-question.block:
+				timer.Stop()
+				break round
+			case <-question.extend:
+				// the round is held open until the latest deadline of the player, the paused round has no timeout
+				g.mu.RLock()
+				deadline, paused := g.deadline, g.paused
+				g.mu.RUnlock()
 
-		select {
-		case <-question.block:
-		case <-timeout:
+				if !timer.Stop() {
+					select {
+					case <-timer.C():
+					default:
+					}
+				}
+
+				timeout = nil
+				if !paused {
+					timer.Reset(deadline.Sub(g.clock.Now()))
+					timeout = timer.C()
+				}
+			case <-timeout:
+				break round
+			}
 		}
 
-		// fmt.Printf("total answer %v\n", len(g.questions[i].playerRetries))
+		g.setAction(endRound, nil)
 	}
-
-	g.setAction(finish, nil)
 }
 
 // SubmitAnswer ...
 func (g *GamePlay) SubmitAnswer(answer SubmitAnswerPayload) {
-	if g.state != OnProgress {
-		return
-	}
-
+	g.mu.RLock()
 	_, ok := g.players[answer.Name]
-	if !ok {
+	ok = ok && g.canAnswer(answer.Name)
+	onProgress := g.state == OnProgress
+	g.mu.RUnlock()
+
+	if !onProgress || !ok {
 		return
 	}
 
 	g.setAction(answerQuestion, answer)
 }
 
+// UseLifeline return error when the lifeline can't be used in the game
+func (g *GamePlay) UseLifeline(payload LifelinePayload) error {
+	if _, ok := g.gameMode().(buzzer); ok {
+		return fmt.Errorf("lifeline is not available in %s mode", BuzzerMode)
+	}
+
+	g.mu.RLock()
+	_, ok := g.players[payload.Name]
+	onProgress := g.state == OnProgress
+	g.mu.RUnlock()
+
+	if !onProgress || !ok {
+		return errors.New("lifeline can only be used when the game is on progress")
+	}
+
+	if err := g.CanAnswer(payload.Name); err != nil {
+		return err
+	}
+
+	g.setAction(useLifeline, payload)
+
+	return nil
+}
+
+// Lifelines is the lifeline used in the game
+func (g *GamePlay) Lifelines() []LifelineUsage {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.lifelines.history()
+}
+
+// Buzz return error when the game can't be buzzed in
+func (g *GamePlay) Buzz(payload BuzzPayload) error {
+	if _, ok := g.gameMode().(buzzer); !ok {
+		return fmt.Errorf("buzzer is only available in %s mode", BuzzerMode)
+	}
+
+	g.mu.RLock()
+	onProgress := g.state == OnProgress
+	g.mu.RUnlock()
+
+	if !onProgress {
+		return errors.New("the game is not on progress")
+	}
+
+	g.setAction(buzz, payload)
+
+	return nil
+}
+
 // AddPlayer ...
-func (g *GamePlay) AddPlayer(name string) { g.players[name] = 0 }
+func (g *GamePlay) AddPlayer(name string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.players[name] = 0
+}
 
 // RemovePlayer ...
-func (g *GamePlay) RemovePlayer(name string) { delete(g.players, name) }
+func (g *GamePlay) RemovePlayer(name string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.players, name)
+}
 
 // ListenStream ...
 func (g *GamePlay) ListenStream() <-chan *GameState { return g.externalStream }
 
-// GetState ...
-func (g GamePlay) GetState() {
-	players := []struct {
-		name  string
-		point int
-	}{}
-
-	for j, val := range g.players {
-		players = append(players, struct {
-			name  string
-			point int
-		}{
-			name:  j,
-			point: val,
+// Scores return the point of each player, sorted from the highest point
+func (g *GamePlay) Scores() []PlayerScore {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	players := []PlayerScore{}
+	for name, point := range g.players {
+		players = append(players, PlayerScore{
+			Name:  name,
+			Point: point,
 		})
 	}
 
 	sort.Slice(players, func(i, j int) bool {
-		return players[i].point > players[j].point
+		return players[i].Point > players[j].Point
 	})
 
+	if r, ok := g.gameMode().(ranker); ok {
+		return r.Rank(players)
+	}
+
+	return players
+}
+
+// Answers is the answer of the ended rounds, ordered by the round and the player
+func (g *GamePlay) Answers() []AnswerRecord {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return append([]AnswerRecord{}, g.answers...)
+}
+
+// TeamScores return nil when the game is not in team mode
+func (g *GamePlay) TeamScores() []TeamScore {
+	if g.teams == nil {
+		return nil
+	}
+
+	return g.teams.Scores(g.Scores())
+}
+
+// roundTotal count the question of the sudden death after the total round, it must be called when g.mu is locked
+func (g *GamePlay) roundTotal(round int) int {
+	if round > g.totalRound {
+		return len(g.questions)
+	}
+
+	return g.totalRound
+}
+
+// Snapshot ...
+func (g *GamePlay) Snapshot() GameSnapshot {
+	scores := g.Scores()
+	teams := g.TeamScores()
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	round := 0
+	if g.state != Waiting {
+		round = g.round + 1
+	}
+
+	return GameSnapshot{
+		Mode:       g.gameMode().Name(),
+		State:      g.state,
+		Round:      round,
+		TotalRound: g.roundTotal(round),
+		Scores:     scores,
+		Teams:      teams,
+		Paused:     g.paused,
+		Review:     g.reviewing,
+	}
+}
+
+// GetState ...
+func (g *GamePlay) GetState() {
+	snapshot := g.Snapshot()
+
 	stateGame := "current"
-	if g.state == Done {
+	if snapshot.State == Done {
 		stateGame = "final"
 	}
 
 	fmt.Printf("=== %v point ===\n", stateGame)
 
-	for i := 0; i < len(players); i++ {
-		fmt.Printf("player: %v point %v\n", players[i].name, players[i].point)
+	for i := 0; i < len(snapshot.Scores); i++ {
+		eliminated := ""
+		if snapshot.Scores[i].Eliminated {
+			eliminated = " (eliminated)"
+		}
+		fmt.Printf("player: %v point %v%s\n", snapshot.Scores[i].Name, snapshot.Scores[i].Point, eliminated)
+	}
+
+	for i := 0; i < len(snapshot.Teams); i++ {
+		fmt.Printf("team: %v point %.2f\n", snapshot.Teams[i].Name, snapshot.Teams[i].Point)
 	}
 }
@@ -0,0 +1,621 @@
+package usecase
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+// harness play the game with the fake clock, the event is read in the order it is emitted
+type harness struct {
+	t     *testing.T
+	clock *ManualClock
+	game  *GamePlay
+}
+
+// idleTimeout fail the test when the game emit nothing, the game itself never wait in real time
+const idleTimeout = time.Second
+
+func newHarness(t *testing.T, questions []QuestionPayload, players ...string) *harness {
+	t.Helper()
+
+	clock := NewManualClock(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	game, err := NewGamePlay(GameConfig{Questions: questions, Clock: clock})
+	if err != nil {
+		t.Fatalf("NewGamePlay() error = %v", err)
+	}
+
+	for _, player := range players {
+		game.AddPlayer(player)
+	}
+	game.Start()
+
+	return &harness{t: t, clock: clock, game: game}
+}
+
+func (h *harness) next() *GameState {
+	h.t.Helper()
+
+	select {
+	case state := <-h.game.ListenStream():
+		return state
+	case <-time.After(idleTimeout):
+		h.t.Fatalf("no event after %s", idleTimeout)
+		return nil
+	}
+}
+
+// expect skip the event until the payload of type T
+func expect[T any](h *harness) T {
+	h.t.Helper()
+
+	for {
+		state := h.next()
+		if state.State == Done {
+			var zero T
+			h.t.Fatalf("the game is finished before %T", zero)
+		}
+
+		if payload, ok := state.payload.(T); ok {
+			return payload
+		}
+	}
+}
+
+// expectMessage skip the event until the personal message of the player
+func (h *harness) expectMessage(name string) string {
+	h.t.Helper()
+
+	for {
+		if message := expect[BroadcastPersonalPayload](h); message.Name == name {
+			return message.Message
+		}
+	}
+}
+
+func (h *harness) expectDone() {
+	h.t.Helper()
+
+	for h.next().State != Done {
+	}
+}
+
+// waitTimer wait until the round timer is reset to the time, the timer is reset by the game goroutine
+func (h *harness) waitTimer(when time.Time) {
+	h.t.Helper()
+
+	deadline := time.Now().Add(idleTimeout)
+	for !h.clock.Next().Equal(when) {
+		if time.Now().After(deadline) {
+			h.t.Fatalf("the next timer is %s, want %s", h.clock.Next(), when)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (h *harness) answer(name, answer string) {
+	h.game.SubmitAnswer(SubmitAnswerPayload{Name: name, Answer: answer})
+}
+
+func TestGamePlayRound(t *testing.T) {
+	trueFalse := func(question, answer string) QuestionPayload {
+		return QuestionPayload{question: question, answer: answer}
+	}
+
+	tests := []struct {
+		name      string
+		questions []QuestionPayload
+		players   []string
+		play      func(h *harness)
+		want      map[string]int
+	}{
+		{
+			name:      "the round is ended by the timeout when nobody answer",
+			questions: []QuestionPayload{trueFalse("q1", "Y"), trueFalse("q2", "N")},
+			players:   []string{"ann"},
+			play: func(h *harness) {
+				question := expect[QuestionEvent](h)
+				if want := h.clock.Now().Add(DefaultTimePerRound); !question.Deadline.Equal(want) {
+					h.t.Errorf("deadline = %s, want %s", question.Deadline, want)
+				}
+
+				h.clock.Advance(DefaultTimePerRound)
+				if result := expect[RoundResult](h); result.Round != 1 || result.TotalAnswer != 0 {
+					h.t.Errorf("round result = %+v, want round 1 without answer", result)
+				}
+
+				if question := expect[QuestionEvent](h); question.Round != 2 {
+					h.t.Errorf("next round = %d, want 2", question.Round)
+				}
+				h.clock.Advance(DefaultTimePerRound)
+				h.expectDone()
+			},
+			want: map[string]int{"ann": 0},
+		},
+		{
+			name:      "the round is advanced early when every player answered",
+			questions: []QuestionPayload{trueFalse("q1", "Y"), trueFalse("q2", "N")},
+			players:   []string{"ann", "bob"},
+			play: func(h *harness) {
+				expect[QuestionEvent](h)
+				h.answer("ann", "Y")
+				h.answer("bob", "N")
+				if result := expect[RoundResult](h); result.TotalAnswer != 2 {
+					h.t.Errorf("total answer = %d, want 2", result.TotalAnswer)
+				}
+
+				// the clock never moved, the next round start at the same time
+				question := expect[QuestionEvent](h)
+				if want := h.clock.Now().Add(DefaultTimePerRound); question.Round != 2 || !question.Deadline.Equal(want) {
+					h.t.Errorf("next round = %d deadline %s, want round 2 deadline %s", question.Round, question.Deadline, want)
+				}
+				h.answer("ann", "N")
+				h.answer("bob", "N")
+				h.expectDone()
+			},
+			want: map[string]int{"ann": 2, "bob": 1},
+		},
+		{
+			name:      "the late answer is not counted while the round is held open by the extra time",
+			questions: []QuestionPayload{trueFalse("q1", "Y")},
+			players:   []string{"ann", "bob"},
+			play: func(h *harness) {
+				question := expect[QuestionEvent](h)
+				if err := h.game.UseLifeline(LifelinePayload{Name: "ann", Lifeline: ExtraTime}); err != nil {
+					h.t.Fatalf("UseLifeline() error = %v", err)
+				}
+				h.expectMessage("ann")
+				h.waitTimer(question.Deadline.Add(ExtraTimeDuration))
+
+				h.clock.Advance(DefaultTimePerRound + time.Second)
+				h.answer("bob", "Y")
+				if message := h.expectMessage("bob"); message != "time is up, your answer is not counted" {
+					h.t.Errorf("message = %q, want the answer is not counted", message)
+				}
+
+				h.answer("ann", "Y")
+				if result := expect[RoundResult](h); result.TotalAnswer != 1 {
+					h.t.Errorf("total answer = %d, want 1", result.TotalAnswer)
+				}
+				h.expectDone()
+			},
+			want: map[string]int{"ann": 1, "bob": 0},
+		},
+		{
+			name:      "the answer of the player who left is not counted in the progress",
+			questions: []QuestionPayload{trueFalse("q1", "Y")},
+			players:   []string{"ann", "bob", "cat"},
+			play: func(h *harness) {
+				expect[QuestionEvent](h)
+				h.answer("ann", "Y")
+				if progress := expect[AnswerProgress](h); progress.TotalAnswer != 1 || progress.TotalPlayer != 3 {
+					h.t.Errorf("progress = %+v, want 1/3", progress)
+				}
+
+				h.game.RemovePlayer("ann")
+				h.answer("bob", "Y")
+				if progress := expect[AnswerProgress](h); progress.TotalAnswer != 1 || progress.TotalPlayer != 2 {
+					h.t.Errorf("progress = %+v, want 1/2", progress)
+				}
+
+				h.answer("cat", "N")
+				h.expectDone()
+			},
+			want: map[string]int{"bob": 1, "cat": 0},
+		},
+		{
+			name:      "the time limit and the weight of the question override the round",
+			questions: []QuestionPayload{{question: "q1", answer: "Y", timeLimit: 30 * time.Second, weight: 3}},
+			players:   []string{"ann"},
+			play: func(h *harness) {
+				question := expect[QuestionEvent](h)
+				if question.TimeLimit != 30*time.Second || question.Points != 3*PointPerAnswer {
+					h.t.Errorf("time limit %s points %d, want 30s and %d points", question.TimeLimit, question.Points, 3*PointPerAnswer)
+				}
+
+				h.clock.Advance(DefaultTimePerRound + time.Second)
+				h.answer("ann", "Y")
+				h.expectDone()
+			},
+			want: map[string]int{"ann": 3},
+		},
+		{
+			name:      "the paused round keep the remaining time",
+			questions: []QuestionPayload{trueFalse("q1", "Y")},
+			players:   []string{"ann"},
+			play: func(h *harness) {
+				question := expect[QuestionEvent](h)
+				h.clock.Advance(4 * time.Second)
+				if err := h.game.Control(PauseRound, 0); err != nil {
+					h.t.Fatalf("Control() error = %v", err)
+				}
+				if paused := expect[RoundPaused](h); paused.Remaining != 6*time.Second {
+					h.t.Errorf("remaining = %s, want 6s", paused.Remaining)
+				}
+
+				h.clock.Advance(time.Minute)
+				h.answer("ann", "Y")
+				if message := h.expectMessage("ann"); message != ErrPaused.Error() {
+					h.t.Errorf("message = %q, want %q", message, ErrPaused)
+				}
+
+				if err := h.game.Control(ResumeRound, 0); err != nil {
+					h.t.Fatalf("Control() error = %v", err)
+				}
+				resumed := expect[RoundResumed](h)
+				if want := question.Deadline.Add(time.Minute); !resumed.Deadline.Equal(want) {
+					h.t.Errorf("deadline = %s, want %s", resumed.Deadline, want)
+				}
+				h.waitTimer(resumed.Deadline)
+
+				h.clock.Advance(6 * time.Second)
+				if result := expect[RoundResult](h); result.TotalAnswer != 0 {
+					h.t.Errorf("total answer = %d, want 0", result.TotalAnswer)
+				}
+				h.expectDone()
+			},
+			want: map[string]int{"ann": 0},
+		},
+		{
+			name:      "the skipped round count the answer before the skip",
+			questions: []QuestionPayload{trueFalse("q1", "Y")},
+			players:   []string{"ann", "bob"},
+			play: func(h *harness) {
+				expect[QuestionEvent](h)
+				h.answer("ann", "Y")
+				expect[AnswerProgress](h)
+
+				if err := h.game.Control(SkipRound, 0); err != nil {
+					h.t.Fatalf("Control() error = %v", err)
+				}
+				if message := expect[string](h); message != "the host skipped question 1" {
+					h.t.Errorf("message = %q, want the skipped question", message)
+				}
+				if result := expect[RoundResult](h); result.TotalAnswer != 1 {
+					h.t.Errorf("total answer = %d, want 1", result.TotalAnswer)
+				}
+				h.expectDone()
+			},
+			want: map[string]int{"ann": 1, "bob": 0},
+		},
+		{
+			name:      "the extended round accept the answer after the old deadline",
+			questions: []QuestionPayload{trueFalse("q1", "Y")},
+			players:   []string{"ann"},
+			play: func(h *harness) {
+				question := expect[QuestionEvent](h)
+				if err := h.game.Control(ExtendRound, 5*time.Second); err != nil {
+					h.t.Fatalf("Control() error = %v", err)
+				}
+				resumed := expect[RoundResumed](h)
+				if want := question.Deadline.Add(5 * time.Second); !resumed.Deadline.Equal(want) || resumed.Extended != 5*time.Second {
+					h.t.Errorf("resumed = %+v, want deadline %s extended 5s", resumed, want)
+				}
+				h.waitTimer(resumed.Deadline)
+
+				h.clock.Advance(DefaultTimePerRound + time.Second)
+				h.answer("ann", "Y")
+				h.expectDone()
+			},
+			want: map[string]int{"ann": 1},
+		},
+		{
+			name:      "the paused round is extended by the default time",
+			questions: []QuestionPayload{trueFalse("q1", "Y")},
+			players:   []string{"ann"},
+			play: func(h *harness) {
+				expect[QuestionEvent](h)
+				h.clock.Advance(4 * time.Second)
+				if err := h.game.Control(PauseRound, 0); err != nil {
+					h.t.Fatalf("Control() error = %v", err)
+				}
+				expect[RoundPaused](h)
+
+				if err := h.game.Control(ExtendRound, 0); err != nil {
+					h.t.Fatalf("Control() error = %v", err)
+				}
+				if paused := expect[RoundPaused](h); paused.Remaining != 6*time.Second+DefaultExtendTime {
+					h.t.Errorf("remaining = %s, want %s", paused.Remaining, 6*time.Second+DefaultExtendTime)
+				}
+
+				if err := h.game.Control(ResumeRound, 0); err != nil {
+					h.t.Fatalf("Control() error = %v", err)
+				}
+				resumed := expect[RoundResumed](h)
+				if want := h.clock.Now().Add(6*time.Second + DefaultExtendTime); !resumed.Deadline.Equal(want) {
+					h.t.Errorf("deadline = %s, want %s", resumed.Deadline, want)
+				}
+				h.waitTimer(resumed.Deadline)
+
+				h.clock.Advance(6*time.Second + DefaultExtendTime)
+				h.expectDone()
+			},
+			want: map[string]int{"ann": 0},
+		},
+		{
+			name:      "the control is rejected when it doesn't match the round",
+			questions: []QuestionPayload{trueFalse("q1", "Y")},
+			players:   []string{"ann"},
+			play: func(h *harness) {
+				expect[QuestionEvent](h)
+				if err := h.game.Control(ResumeRound, 0); err == nil {
+					h.t.Error("Control(resume) on the running round, want error")
+				}
+				if err := h.game.Control(AdvanceRound, 0); err == nil {
+					h.t.Error("Control(next) without review, want error")
+				}
+
+				if err := h.game.Control(PauseRound, 0); err != nil {
+					h.t.Fatalf("Control() error = %v", err)
+				}
+				if err := h.game.Control(PauseRound, 0); err == nil {
+					h.t.Error("Control(pause) on the paused round, want error")
+				}
+				if err := h.game.Control(SkipRound, 0); err != nil {
+					h.t.Fatalf("Control() error = %v", err)
+				}
+				h.expectDone()
+
+				if err := h.game.Control(SkipRound, 0); err == nil {
+					h.t.Error("Control(skip) after the game, want error")
+				}
+			},
+			want: map[string]int{"ann": 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHarness(t, tt.questions, tt.players...)
+			tt.play(h)
+
+			got := map[string]int{}
+			for _, score := range h.game.Scores() {
+				got[score.Name] = score.Point
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Scores() = %v, want %v", got, tt.want)
+			}
+
+			if state := h.game.Snapshot().State; state != Done {
+				t.Errorf("state = %v, want done", state)
+			}
+		})
+	}
+}
+
+func TestGamePlayReview(t *testing.T) {
+	clock := NewManualClock(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	game, err := NewGamePlay(GameConfig{
+		Questions: []QuestionPayload{{question: "q1", answer: "Y"}, {question: "q2", answer: "N"}},
+		Clock:     clock,
+		Review:    true,
+	})
+	if err != nil {
+		t.Fatalf("NewGamePlay() error = %v", err)
+	}
+	game.AddPlayer("ann")
+	game.Start()
+	h := &harness{t: t, clock: clock, game: game}
+
+	expect[QuestionEvent](h)
+	h.answer("ann", "Y")
+	if review := expect[RoundReview](h); review.Round != 1 || review.TotalRound != 2 {
+		t.Errorf("review = %+v, want round 1 of 2", review)
+	}
+
+	// the game hold until the host start the next round
+	clock.Advance(time.Minute)
+	if err := game.Control(PauseRound, 0); err == nil {
+		t.Error("Control(pause) in review, want error")
+	}
+	if err := game.Control(AdvanceRound, 0); err != nil {
+		t.Fatalf("Control() error = %v", err)
+	}
+	if question := expect[QuestionEvent](h); question.Round != 2 {
+		t.Errorf("next round = %d, want 2", question.Round)
+	}
+
+	// the last round is not reviewed
+	h.answer("ann", "N")
+	h.expectDone()
+	if scores := game.Scores(); len(scores) != 1 || scores[0].Point != 2*PointPerAnswer {
+		t.Errorf("Scores() = %+v, want ann with %d points", scores, 2*PointPerAnswer)
+	}
+}
+
+func TestGamePlaySkipInReview(t *testing.T) {
+	clock := NewManualClock(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	game, err := NewGamePlay(GameConfig{
+		Questions: []QuestionPayload{{question: "q1", answer: "Y"}, {question: "q2", answer: "N"}},
+		Clock:     clock,
+		Review:    true,
+	})
+	if err != nil {
+		t.Fatalf("NewGamePlay() error = %v", err)
+	}
+	game.AddPlayer("ann")
+	game.AddPlayer("bob")
+	game.Start()
+	h := &harness{t: t, clock: clock, game: game}
+
+	expect[QuestionEvent](h)
+	h.answer("ann", "Y")
+	expect[AnswerProgress](h)
+	if err := game.Control(SkipRound, 0); err != nil {
+		t.Fatalf("Control() error = %v", err)
+	}
+	expect[RoundReview](h)
+
+	// the deadline of the skipped round is not passed, but the round is closed
+	h.answer("bob", "Y")
+	if message := h.expectMessage("bob"); message != "the round is over, your answer is not counted" {
+		t.Errorf("message = %q, want the answer is not counted", message)
+	}
+	if err := game.UseLifeline(LifelinePayload{Name: "bob", Lifeline: ExtraTime}); err != nil {
+		t.Fatalf("UseLifeline() error = %v", err)
+	}
+	if message := h.expectMessage("bob"); message != "wait for the question before using the lifeline" {
+		t.Errorf("message = %q, want the lifeline is rejected", message)
+	}
+
+	if err := game.Control(AdvanceRound, 0); err != nil {
+		t.Fatalf("Control() error = %v", err)
+	}
+	expect[QuestionEvent](h)
+	clock.Advance(DefaultTimePerRound)
+	h.expectDone()
+
+	got := map[string]int{}
+	for _, score := range game.Scores() {
+		got[score.Name] = score.Point
+	}
+	if want := map[string]int{"ann": 1, "bob": 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Scores() = %v, want %v", got, want)
+	}
+	if record := game.Answers()[1]; record.Player != "bob" || record.Answer != "" {
+		t.Errorf("the answer of bob = %+v, want unanswered", record)
+	}
+}
+
+func TestGamePlayScoresOrder(t *testing.T) {
+	h := newHarness(t, []QuestionPayload{{question: "q1", answer: "Y"}}, "ann", "bob", "cat")
+	expect[QuestionEvent](h)
+	h.answer("bob", "Y")
+	h.answer("ann", "N")
+	h.clock.Advance(DefaultTimePerRound)
+	h.expectDone()
+
+	scores := h.game.Scores()
+	if len(scores) != 3 || scores[0].Name != "bob" || scores[0].Point != PointPerAnswer {
+		t.Fatalf("Scores() = %+v, want bob first with %d points", scores, PointPerAnswer)
+	}
+
+	rest := []string{scores[1].Name, scores[2].Name}
+	sort.Strings(rest)
+	if !reflect.DeepEqual(rest, []string{"ann", "cat"}) {
+		t.Errorf("the rest = %v, want ann and cat", rest)
+	}
+}
+
+func TestGamePlayAnswers(t *testing.T) {
+	h := newHarness(t, []QuestionPayload{{question: "q1", answer: "Y"}}, "ann", "bob")
+	expect[QuestionEvent](h)
+	h.clock.Advance(3 * time.Second)
+	h.answer("ann", "Y")
+	expect[AnswerProgress](h)
+	h.clock.Advance(DefaultTimePerRound)
+	h.expectDone()
+
+	// the player who doesn't answer is recorded without answer and latency
+	want := []AnswerRecord{
+		{Round: 1, Question: "q1", CorrectAnswer: "Y", Player: "ann", Answer: "Y", Correct: true, Latency: 3 * time.Second},
+		{Round: 1, Question: "q1", CorrectAnswer: "Y", Player: "bob"},
+	}
+	if got := h.game.Answers(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Answers() = %+v, want %+v", got, want)
+	}
+}
+
+func TestEliminationSuddenDeath(t *testing.T) {
+	clock := NewManualClock(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	game, err := NewGamePlay(GameConfig{
+		Mode:       EliminationMode,
+		TotalRound: 1,
+		Questions:  []QuestionPayload{{question: "q1", answer: "Y"}, {question: "q2", answer: "N"}},
+		Clock:      clock,
+	})
+	if err != nil {
+		t.Fatalf("NewGamePlay() error = %v", err)
+	}
+	game.AddPlayer("ann")
+	game.AddPlayer("bob")
+	game.Start()
+	h := &harness{t: t, clock: clock, game: game}
+
+	if question := expect[QuestionEvent](h); question.Question != "q1" || question.SuddenDeath {
+		t.Fatalf("round 1 = %+v, want q1", question)
+	}
+	h.answer("ann", "Y")
+	h.answer("bob", "Y")
+
+	// the sudden death ask the question which is not asked
+	if question := expect[QuestionEvent](h); question.Question != "q2" || !question.SuddenDeath || question.TotalRound != 2 {
+		t.Fatalf("round 2 = %+v, want the sudden death with q2 of 2 rounds", question)
+	}
+	h.answer("ann", "N")
+	h.answer("bob", "N")
+
+	want := "no question is left for the sudden death, ann, bob share the win"
+	if message := expect[string](h); message != want {
+		t.Errorf("message = %q, want %q", message, want)
+	}
+	h.expectDone()
+}
+
+func TestEliminationReserve(t *testing.T) {
+	questions := func(total int) []QuestionPayload {
+		res := []QuestionPayload{}
+		for i := 0; i < total; i++ {
+			res = append(res, QuestionPayload{question: fmt.Sprintf("q%d", i+1), answer: "Y"})
+		}
+		return res
+	}
+
+	tests := []struct {
+		name        string
+		questions   int
+		totalRound  int
+		wantRound   int
+		wantReserve int
+	}{
+		{name: "the last questions is kept for the sudden death", questions: 10, wantRound: 7, wantReserve: SuddenDeathReserve},
+		{name: "the small game keep half of the questions", questions: 3, wantRound: 2, wantReserve: 1},
+		{name: "the single question has no sudden death", questions: 1, wantRound: 1},
+		{name: "the total round keep the rest of the questions", questions: 10, totalRound: 4, wantRound: 4, wantReserve: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode, _, totalRound, err := newMode(GameConfig{Mode: EliminationMode, TotalRound: tt.totalRound}, questions(tt.questions))
+			if err != nil {
+				t.Fatalf("newMode() error = %v", err)
+			}
+
+			if reserve := len(mode.(*eliminationMode).reserve); totalRound != tt.wantRound || reserve != tt.wantReserve {
+				t.Errorf("total round %d reserve %d, want %d and %d", totalRound, reserve, tt.wantRound, tt.wantReserve)
+			}
+		})
+	}
+}
+
+func TestHintPoint(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    HintConfig
+		weight int
+		hints  int
+		want   int
+	}{
+		{name: "the room without hint keep one point", cfg: HintConfig{}, weight: 1, want: PointPerAnswer},
+		{name: "the weight multiply the point without hint", cfg: HintConfig{Mode: HintOff}, weight: 3, want: 3},
+		{name: "the room with hints start from 10 points", cfg: HintConfig{Mode: HintRequest}, weight: 1, want: HintPoints},
+		{name: "each hint cost 3 points", cfg: HintConfig{Mode: HintAuto}, weight: 2, hints: 2, want: 8},
+		{name: "the point is never negative", cfg: HintConfig{Mode: HintRequest, Cost: 6}, weight: 1, hints: 2, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := newHintConfig(tt.cfg)
+			if err != nil {
+				t.Fatalf("newHintConfig() error = %v", err)
+			}
+
+			if got := cfg.point(tt.weight, tt.hints); got != tt.want {
+				t.Errorf("point() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// PointPerAnswer is the point of the correct answer
+	PointPerAnswer = 1
+	// HintPoints is the point of the correct answer in the room with hints, so the cost of the hint is a fraction of the answer
+	HintPoints = 10
+	// DefaultHintCost is the point reduced for each hint from HintPoints
+	DefaultHintCost = 3
+
+	// HintOff disable the hint, the correct answer is worth PointPerAnswer
+	HintOff = "off"
+	// HintRequest let the player request the next hint privately, the hint is unlocked over time
+	HintRequest = "request"
+	// HintAuto reveal the hint to every player at the fraction of the round time
+	HintAuto = "auto"
+)
+
+type (
+	// HintConfig is ...
+	HintConfig struct {
+		// Mode is off, request or auto. empty mode is off
+		Mode string
+		// Cost is the point reduced for each hint, zero mean the default cost
+		Cost int
+		// Reveal is the fraction of the round time when the hint is unlocked or revealed, e.g. 0.5, 0.75.
+		// Empty mean the hints are spread evenly in the round
+		Reveal []float64
+	}
+
+	// hintReveal is the auto reveal of the hint in the round
+	hintReveal struct {
+		round int
+		index int
+	}
+)
+
+// ParseHintReveal parse the fraction of the round time, e.g. 0.5,0.75
+func ParseHintReveal(s string) ([]float64, error) {
+	reveal := []float64{}
+	if strings.TrimSpace(s) == "" {
+		return reveal, nil
+	}
+
+	for _, field := range strings.Split(s, ",") {
+		fraction, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil || fraction < 0 || fraction >= 1 {
+			return nil, fmt.Errorf("hint reveal %s must be a fraction of the round time, from 0 to 1", field)
+		}
+
+		reveal = append(reveal, fraction)
+	}
+
+	return reveal, nil
+}
+
+func newHintConfig(cfg HintConfig) (HintConfig, error) {
+	switch cfg.Mode {
+	case "":
+		cfg.Mode = HintOff
+	case HintOff, HintRequest, HintAuto:
+	default:
+		return cfg, fmt.Errorf("hint mode %s not found", cfg.Mode)
+	}
+
+	if cfg.Cost <= 0 {
+		cfg.Cost = DefaultHintCost
+	}
+
+	for i := 1; i < len(cfg.Reveal); i++ {
+		if cfg.Reveal[i] < cfg.Reveal[i-1] {
+			return cfg, errors.New("hint reveal must be in ascending order")
+		}
+	}
+
+	return cfg, nil
+}
+
+// hintAt is the time after the round is started when the hint is unlocked
+func (c HintConfig) hintAt(index, total int, round time.Duration) time.Duration {
+	fraction := float64(index+1) / float64(total+1)
+	if index < len(c.Reveal) {
+		fraction = c.Reveal[index]
+	}
+
+	return time.Duration(fraction * float64(round))
+}
+
+// point is the point of the correct answer after the hint multiplied by the weight of the question, it is never negative.
+// The room without hint keep PointPerAnswer
+func (c HintConfig) point(weight, hints int) int {
+	if c.Mode == HintOff {
+		return PointPerAnswer * weight
+	}
+
+	point := HintPoints - hints*c.Cost
+	if point < 0 {
+		return 0
+	}
+
+	return point * weight
+}
+
+// hintTotal is the hint the player can request, the auto revealed hint is announced instead
+func (g *GamePlay) hintTotal(question QuestionPayload) int {
+	if g.hints.Mode != HintRequest {
+		return 0
+	}
+
+	return len(question.hints)
+}
+
+// scheduleHints reveal the hint of the round in auto mode, the revealed hint is not scheduled again
+func (g *GamePlay) scheduleHints(question QuestionPayload) {
+	if g.hints.Mode != HintAuto {
+		return
+	}
+
+	for i := question.revealed; i < len(question.hints); i++ {
+		reveal := hintReveal{round: question.round, index: i}
+		g.clock.AfterFunc(g.revealAt(question, i).Sub(g.clock.Now()), func() {
+			g.setAction(revealHint, reveal)
+		})
+	}
+}
+
+// revealAt is the time of the hint from the start of the round, the start is moved by the pause
+func (g *GamePlay) revealAt(question QuestionPayload, index int) time.Time {
+	return question.started.Add(g.hints.hintAt(index, len(question.hints), question.timeOf(g.timePerRound)))
+}
+
+// revealHint send the hint to every player, the answer after the hint get less point
+func (g *GamePlay) revealHint(reveal hintReveal) {
+	g.mu.Lock()
+	if g.expected.closed || g.expected.round != reveal.round || g.expected.revealed != reveal.index {
+		g.mu.Unlock()
+		return
+	}
+
+	// the hint is scheduled again when the round is resumed
+	if g.paused {
+		g.mu.Unlock()
+		return
+	}
+
+	// the round was paused after the hint is scheduled
+	if wait := g.revealAt(g.expected, reveal.index).Sub(g.clock.Now()); wait > 0 {
+		g.mu.Unlock()
+		g.clock.AfterFunc(wait, func() {
+			g.setAction(revealHint, reveal)
+		})
+		return
+	}
+	g.expected.revealed++
+	hint := g.expected.hints[reveal.index]
+	total := len(g.expected.hints)
+	weight := g.expected.weightOf()
+	g.mu.Unlock()
+
+	g.externalStream <- &GameState{
+		State:   OnProgress,
+		payload: fmt.Sprintf("hint %d/%d: %s (the correct answer is now worth %d points)", reveal.index+1, total, hint, g.hints.point(weight, reveal.index+1)),
+	}
+}
+
+// requestHint send the next hint privately to the player
+func (g *GamePlay) requestHint(name string) {
+	g.mu.Lock()
+	message, err := g.nextHint(name)
+	g.mu.Unlock()
+
+	if err != nil {
+		message = err.Error()
+	}
+
+	g.sendPersonal(name, message)
+}
+
+// nextHint must be called when g.mu is locked
+func (g *GamePlay) nextHint(name string) (string, error) {
+	if g.expected.playerRetries == nil || g.expected.closed {
+		return "", errors.New("wait for the question before requesting the hint")
+	}
+
+	if _, answered := g.expected.playerRetries[name]; answered {
+		return "", errors.New("you already answered the question")
+	}
+
+	if g.paused {
+		return "", ErrPaused
+	}
+
+	used := g.expected.hintsUsed[name]
+	if used >= len(g.expected.hints) {
+		return "", errors.New("no more hint for this question")
+	}
+
+	// the next hint is unlocked over time
+	unlockAt := g.expected.started.Add(g.hints.hintAt(used, len(g.expected.hints), g.expected.timeOf(g.timePerRound)))
+	if wait := unlockAt.Sub(g.clock.Now()); wait > 0 {
+		return "", fmt.Errorf("the next hint is unlocked in %s", time.Duration(math.Ceil(wait.Seconds()))*time.Second)
+	}
+
+	g.expected.hintsUsed[name] = used + 1
+
+	return fmt.Sprintf("hint %d/%d: %s (the correct answer is now worth %d points)",
+		used+1, len(g.expected.hints), g.expected.hints[used], g.hints.point(g.expected.weightOf(), used+1)), nil
+}
+
+// hintsOf is total hint seen by the player, it must be called when g.mu is locked
+func (g *GamePlay) hintsOf(name string) int {
+	if g.hints.Mode == HintAuto {
+		return g.expected.revealed
+	}
+
+	return g.expected.hintsUsed[name]
+}
+
+// RequestHint return error when the hint can't be requested in the game
+func (g *GamePlay) RequestHint(name string) error {
+	if g.hints.Mode != HintRequest {
+		return errors.New("the hint can't be requested in this game")
+	}
+
+	g.mu.RLock()
+	_, ok := g.players[name]
+	onProgress := g.state == OnProgress
+	g.mu.RUnlock()
+
+	if !onProgress || !ok {
+		return errors.New("the hint can only be requested when the game is on progress")
+	}
+
+	// in buzzer mode the hint can be requested before buzzing
+	if err := g.CanAnswer(name); err != nil && !errors.Is(err, ErrNotBuzzed) {
+		return err
+	}
+
+	g.setAction(requestHint, name)
+
+	return nil
+}
@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// FiftyFifty remove two wrong options of the multiple-choice question
+	FiftyFifty = "fifty"
+	// SkipQuestion pass the question without penalty
+	SkipQuestion = "skip"
+	// DoublePoints double the point of the answer
+	DoublePoints = "double"
+	// ExtraTime extend the deadline of the player
+	ExtraTime = "time"
+
+	// ExtraTimeDuration is the time added by the extra time lifeline
+	ExtraTimeDuration = 10 * time.Second
+)
+
+type (
+	// LifelinePayload is sent when the player use the lifeline in the round
+	LifelinePayload struct {
+		Name     string
+		Lifeline string
+	}
+
+	// LifelineUsage is recorded in the result of the game
+	LifelineUsage struct {
+		Player   string
+		Lifeline string
+		Round    int
+	}
+
+	// lifelines is the usage of the lifeline of each player in a game
+	lifelines struct {
+		limit  map[string]int
+		used   map[string]map[string]int
+		usages []LifelineUsage
+	}
+)
+
+// DefaultLifelines is one of each lifeline for every player
+func DefaultLifelines() map[string]int {
+	return map[string]int{
+		FiftyFifty:   1,
+		SkipQuestion: 1,
+		DoublePoints: 1,
+		ExtraTime:    1,
+	}
+}
+
+// LifelineLimit validate the usage count of the lifeline,
+// the lifeline which is not in the counts use the default count
+func LifelineLimit(counts map[string]int) (map[string]int, error) {
+	limit := DefaultLifelines()
+	for name, count := range counts {
+		if _, ok := limit[name]; !ok {
+			return nil, fmt.Errorf("lifeline %s not found", name)
+		}
+
+		if count < 0 {
+			return nil, fmt.Errorf("count of lifeline %s must not be negative", name)
+		}
+
+		limit[name] = count
+	}
+
+	return limit, nil
+}
+
+// ParseLifelines parse the usage count of the lifeline, e.g. fifty=1,skip=2,double=0,time=1
+func ParseLifelines(s string) (map[string]int, error) {
+	counts := map[string]int{}
+	if strings.TrimSpace(s) == "" {
+		return LifelineLimit(counts)
+	}
+
+	for _, field := range strings.Split(s, ",") {
+		name, count, ok := strings.Cut(strings.TrimSpace(field), "=")
+		if !ok {
+			return nil, fmt.Errorf("lifeline %s must be written as name=count", field)
+		}
+
+		n, err := strconv.Atoi(count)
+		if err != nil {
+			return nil, fmt.Errorf("count of lifeline %s must be a number", name)
+		}
+
+		counts[name] = n
+	}
+
+	return LifelineLimit(counts)
+}
+
+func newLifelines(limit map[string]int) *lifelines {
+	if limit == nil {
+		limit = DefaultLifelines()
+	}
+
+	return &lifelines{
+		limit: limit,
+		used:  map[string]map[string]int{},
+	}
+}
+
+// use return error when the player run out of the lifeline
+func (l *lifelines) use(player, lifeline string, round int) error {
+	limit, ok := l.limit[lifeline]
+	if !ok {
+		return fmt.Errorf("lifeline %s not found, use /fifty, /skip, /double or /time", lifeline)
+	}
+
+	if l.used[player] == nil {
+		l.used[player] = map[string]int{}
+	}
+
+	if l.used[player][lifeline] >= limit {
+		return fmt.Errorf("you have no %s lifeline left", lifeline)
+	}
+
+	l.used[player][lifeline]++
+	l.usages = append(l.usages, LifelineUsage{
+		Player:   player,
+		Lifeline: lifeline,
+		Round:    round,
+	})
+
+	return nil
+}
+
+// remaining is the lifeline left for the player, e.g. fifty 1, skip 0
+func (l *lifelines) remaining(player string) string {
+	names := []string{}
+	for name := range l.limit {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	left := []string{}
+	for _, name := range names {
+		left = append(left, fmt.Sprintf("%s %d", name, l.limit[name]-l.used[player][name]))
+	}
+
+	return strings.Join(left, ", ")
+}
+
+// history is the usage in the order of the use
+func (l *lifelines) history() []LifelineUsage {
+	return append([]LifelineUsage{}, l.usages...)
+}
+
+// useLifeline apply the lifeline to the current round, the result is only sent to the player
+func (g *GamePlay) useLifeline(payload LifelinePayload) {
+	g.mu.Lock()
+	message, progress, err := g.applyLifeline(payload)
+	allAnswered := err == nil && payload.Lifeline == SkipQuestion && g.allAnswered()
+	g.mu.Unlock()
+
+	if err != nil {
+		g.sendPersonal(payload.Name, err.Error())
+		return
+	}
+
+	g.sendPersonal(payload.Name, message)
+	if progress != nil {
+		g.externalStream <- &GameState{
+			State:   OnProgress,
+			payload: *progress,
+		}
+	}
+
+	if allAnswered {
+		g.endRoundEarly()
+	}
+}
+
+// applyLifeline must be called when g.mu is locked
+func (g *GamePlay) applyLifeline(payload LifelinePayload) (string, *AnswerProgress, error) {
+	name := payload.Name
+	if g.expected.playerRetries == nil || g.expected.closed {
+		return "", nil, errors.New("wait for the question before using the lifeline")
+	}
+
+	if g.paused {
+		return "", nil, ErrPaused
+	}
+
+	if _, answered := g.expected.playerRetries[name]; answered {
+		return "", nil, errors.New("you already answered the question")
+	}
+
+	if g.clock.Now().After(g.deadlineOf(name)) {
+		return "", nil, errors.New("time is up")
+	}
+
+	switch payload.Lifeline {
+	case FiftyFifty:
+		if len(g.expected.options) < 3 {
+			return "", nil, errors.New("50/50 is only for the multiple-choice question")
+		}
+	case DoublePoints:
+		if g.expected.doubled[name] {
+			return "", nil, errors.New("double points is already used in this round")
+		}
+	}
+
+	if err := g.lifelines.use(name, payload.Lifeline, g.expected.round+1); err != nil {
+		return "", nil, err
+	}
+	left := g.lifelines.remaining(name)
+
+	switch payload.Lifeline {
+	case FiftyFifty:
+		// at least one wrong option is kept
+		wrong := g.expected.wrongKeys()
+		g.rand.Shuffle(len(wrong), func(i, j int) { wrong[i], wrong[j] = wrong[j], wrong[i] })
+		total := 2
+		if len(wrong)-1 < total {
+			total = len(wrong) - 1
+		}
+		removed := wrong[:total]
+		sort.Strings(removed)
+
+		return fmt.Sprintf("50/50: option %s is removed. lifeline left: %s", strings.Join(removed, ", "), left), nil, nil
+	case SkipQuestion:
+		g.expected.skipped[name] = true
+		g.expected.playerRetries[name] = 0
+		progress := g.answerProgress()
+
+		return fmt.Sprintf("you skipped the question. lifeline left: %s", left), &progress, nil
+	case DoublePoints:
+		g.expected.doubled[name] = true
+
+		return fmt.Sprintf("your answer is worth double points. lifeline left: %s", left), nil, nil
+	default:
+		deadline := g.deadlineOf(name).Add(ExtraTimeDuration)
+		g.expected.deadlines[name] = deadline
+		if deadline.After(g.deadline) {
+			g.deadline = deadline
+			select {
+			case g.expected.extend <- true:
+			default:
+			}
+		}
+
+		return fmt.Sprintf("you have %s extra time, answer before %s. lifeline left: %s",
+			ExtraTimeDuration, deadline.Format(time.TimeOnly), left), nil, nil
+	}
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxQuestionLength is the longest question shown in one line of the terminal
+	MaxQuestionLength = 200
+	// MaxOptionLength is ...
+	MaxOptionLength = 80
+
+	// LintError make the question unplayable or the game unfair
+	LintError = "error"
+	// LintWarning is ...
+	LintWarning = "warning"
+)
+
+// ambiguousOptions refer to the other options, the meaning is changed when the options are shuffled
+var ambiguousOptions = []string{"all of the above", "none of the above", "both a and b", "a and b"}
+
+// LintIssue is ...
+type LintIssue struct {
+	// Question is the number of the question in the bank, zero is the bank
+	Question int
+	Severity string
+	Message  string
+}
+
+func (i LintIssue) String() string {
+	if i.Question == 0 {
+		return fmt.Sprintf("%s: %s", i.Severity, i.Message)
+	}
+
+	return fmt.Sprintf("question %d %s: %s", i.Question, i.Severity, i.Message)
+}
+
+// Lint check every question of the bank, the invalid question and the duplicate is an error
+func (b *QuestionBank) Lint() []LintIssue {
+	issues := []LintIssue{}
+	report := func(question int, severity, format string, args ...any) {
+		issues = append(issues, LintIssue{Question: question, Severity: severity, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(b.Questions) == 0 {
+		report(0, LintError, "the bank has no question")
+	}
+
+	ids := map[string]int{}
+	texts := map[string]int{}
+	for i, question := range b.Questions {
+		n := i + 1
+
+		if question.ID != "" {
+			if first, ok := ids[question.ID]; ok {
+				report(n, LintError, "duplicate id %s of question %d", question.ID, first)
+			} else {
+				ids[question.ID] = n
+			}
+		}
+
+		text := normalize(question.Question)
+		if first, ok := texts[text]; ok {
+			report(n, LintWarning, "duplicate of question %d", first)
+		} else {
+			texts[text] = n
+		}
+
+		if len(question.Options) > 0 && strings.TrimSpace(question.Answer) == "" {
+			report(n, LintError, "the options have no correct answer")
+		} else if err := question.Validate(); err != nil {
+			report(n, LintError, "%s", err)
+		}
+
+		if len(b.Categories) > 0 && question.Category != "" && !contains(b.Categories, question.Category) {
+			report(n, LintError, "category %s is not declared in the bank", question.Category)
+		}
+
+		options := map[string]string{}
+		for j, option := range question.Options {
+			key := string(rune('A' + j))
+			if strings.TrimSpace(option) == "" {
+				report(n, LintError, "option %s is empty", key)
+				continue
+			}
+
+			if first, ok := options[normalize(option)]; ok {
+				report(n, LintError, "option %s and %s are the same, the answer is ambiguous", first, key)
+			} else {
+				options[normalize(option)] = key
+			}
+
+			if contains(ambiguousOptions, normalize(option)) {
+				report(n, LintWarning, "option %s %q is ambiguous when the options are shuffled", key, option)
+			}
+
+			if utf8.RuneCountInString(option) > MaxOptionLength {
+				report(n, LintWarning, "option %s is longer than %d characters", key, MaxOptionLength)
+			}
+		}
+
+		if utf8.RuneCountInString(question.Question) > MaxQuestionLength {
+			report(n, LintWarning, "question is longer than %d characters", MaxQuestionLength)
+		}
+
+		if strings.TrimSpace(question.Explanation) == "" {
+			report(n, LintWarning, "missing explanation")
+		}
+	}
+
+	return issues
+}
+
+// normalize ignore the case and the spaces when comparing the text
+func normalize(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
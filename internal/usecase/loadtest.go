@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	quiz "github.com/elangreza14/grpc-quiz/proto"
+)
+
+type (
+	// LatencyDist is the distribution of the answer delay of the simulated player,
+	// e.g. fixed:2s, uniform:1s-5s, normal:3s,1s (mean and standard deviation) or exp:2s (mean)
+	LatencyDist struct {
+		kind string
+		a, b time.Duration
+	}
+
+	// AccuracyRange is the chance of the correct answer, every simulated player get the accuracy between min and max
+	AccuracyRange struct {
+		Min, Max float64
+	}
+
+	// AnswerKey is the answer of the question known by the simulated player, the question is identified by the text
+	AnswerKey map[string]QuestionPayload
+
+	// LatencySummary is the percentile of the latency
+	LatencySummary struct {
+		Count         int
+		P50, P99, Max time.Duration
+	}
+)
+
+// DefaultAnswerLatency is the answer delay of the simulated player when it is not set
+const DefaultAnswerLatency = "uniform:1s-5s"
+
+// ParseLatencyDist parse the distribution, the plain duration is fixed
+func ParseLatencyDist(value string) (LatencyDist, error) {
+	kind, args, ok := strings.Cut(strings.TrimSpace(value), ":")
+	if !ok {
+		kind, args = "fixed", kind
+	}
+
+	durations := func(sep string, total int) ([]time.Duration, error) {
+		parts := strings.Split(args, sep)
+		if len(parts) != total {
+			return nil, fmt.Errorf("latency %s: %s need %d durations", value, kind, total)
+		}
+
+		res := []time.Duration{}
+		for _, part := range parts {
+			d, err := time.ParseDuration(strings.TrimSpace(part))
+			if err != nil || d < 0 {
+				return nil, fmt.Errorf("latency %s: %s is not a positive duration", value, part)
+			}
+			res = append(res, d)
+		}
+
+		return res, nil
+	}
+
+	var (
+		d   []time.Duration
+		err error
+	)
+	switch kind {
+	case "fixed", "exp":
+		d, err = durations(",", 1)
+		if err == nil {
+			d = append(d, 0)
+		}
+	case "uniform":
+		d, err = durations("-", 2)
+		if err == nil && d[0] > d[1] {
+			err = fmt.Errorf("latency %s: the min is greater than the max", value)
+		}
+	case "normal":
+		d, err = durations(",", 2)
+	default:
+		err = fmt.Errorf("latency %s: distribution %s not found, use fixed, uniform, normal or exp", value, kind)
+	}
+	if err != nil {
+		return LatencyDist{}, err
+	}
+
+	return LatencyDist{kind: kind, a: d[0], b: d[1]}, nil
+}
+
+// Sample is the random delay of the distribution, it is never negative
+func (d LatencyDist) Sample(r *rand.Rand) time.Duration {
+	var sample float64
+	switch d.kind {
+	case "uniform":
+		sample = float64(d.a) + r.Float64()*float64(d.b-d.a)
+	case "normal":
+		sample = float64(d.a) + r.NormFloat64()*float64(d.b)
+	case "exp":
+		sample = r.ExpFloat64() * float64(d.a)
+	default:
+		sample = float64(d.a)
+	}
+
+	return time.Duration(math.Max(sample, 0))
+}
+
+func (d LatencyDist) String() string {
+	switch d.kind {
+	case "uniform":
+		return fmt.Sprintf("uniform:%s-%s", d.a, d.b)
+	case "normal":
+		return fmt.Sprintf("normal:%s,%s", d.a, d.b)
+	default:
+		return fmt.Sprintf("%s:%s", d.kind, d.a)
+	}
+}
+
+// ParseAccuracyRange parse the accuracy, e.g. 0.7 or 0.5-0.9
+func ParseAccuracyRange(value string) (AccuracyRange, error) {
+	low, high, ok := strings.Cut(strings.TrimSpace(value), "-")
+	if !ok {
+		high = low
+	}
+
+	res := AccuracyRange{}
+	for _, v := range []struct {
+		text  string
+		value *float64
+	}{{low, &res.Min}, {high, &res.Max}} {
+		accuracy, err := strconv.ParseFloat(strings.TrimSpace(v.text), 64)
+		if err != nil || accuracy < 0 || accuracy > 1 {
+			return AccuracyRange{}, fmt.Errorf("accuracy %s must be from 0 to 1", v.text)
+		}
+		*v.value = accuracy
+	}
+
+	if res.Min > res.Max {
+		return AccuracyRange{}, fmt.Errorf("accuracy %s: the min is greater than the max", value)
+	}
+
+	return res, nil
+}
+
+// Sample is the accuracy of one player
+func (a AccuracyRange) Sample(r *rand.Rand) float64 {
+	return a.Min + r.Float64()*(a.Max-a.Min)
+}
+
+// NewAnswerKey is the answer of the questions, the default questions are used when it is empty
+func NewAnswerKey(questions []QuestionPayload) AnswerKey {
+	if len(questions) == 0 {
+		questions = defaultQuestions()
+	}
+
+	key := AnswerKey{}
+	for _, question := range questions {
+		key[question.question] = question
+	}
+
+	return key
+}
+
+// Answer pick the correct or the wrong key of the question, the unknown question is answered randomly
+func (k AnswerKey) Answer(question *quiz.Question, correct bool, r *rand.Rand) string {
+	keys := trueFalseKeys
+	if len(question.Options) > 0 {
+		keys = []string{}
+		for _, option := range question.Options {
+			keys = append(keys, option.Key)
+		}
+	}
+
+	q, ok := k[question.Question]
+	if !ok {
+		return keys[r.Intn(len(keys))]
+	}
+
+	if correct {
+		return q.answer
+	}
+
+	wrong := []string{}
+	for _, key := range keys {
+		if key != q.answer {
+			wrong = append(wrong, key)
+		}
+	}
+	if len(wrong) == 0 {
+		return q.answer
+	}
+
+	return wrong[r.Intn(len(wrong))]
+}
+
+// SummarizeLatency is the p50, p99 and the max of the latency
+func SummarizeLatency(latencies []time.Duration) LatencySummary {
+	if len(latencies) == 0 {
+		return LatencySummary{}
+	}
+
+	sorted := append([]time.Duration{}, latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	percentile := func(p float64) time.Duration {
+		return sorted[int(math.Ceil(p*float64(len(sorted))))-1]
+	}
+
+	return LatencySummary{
+		Count: len(sorted),
+		P50:   percentile(0.5),
+		P99:   percentile(0.99),
+		Max:   sorted[len(sorted)-1],
+	}
+}
+
+func (s LatencySummary) String() string {
+	if s.Count == 0 {
+		return "no sample"
+	}
+
+	return fmt.Sprintf("p50 %s, p99 %s, max %s (%d samples)", s.P50.Round(time.Microsecond), s.P99.Round(time.Microsecond), s.Max.Round(time.Microsecond), s.Count)
+}
@@ -0,0 +1,342 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// DefaultRoom is the room hosted from the server terminal
+const DefaultRoom = "default"
+
+// Lobby is collection of the room in the server
+type Lobby struct {
+	mu      sync.RWMutex
+	rooms   map[string]*Room
+	total   int
+	created chan *Room
+	Results *ResultStore
+	Ratings *RatingStore
+	// Matchmaker pair the player for 1v1 duel
+	Matchmaker *Matchmaker
+	// bank is the question of the room without questions, nil mean the default question
+	bank []QuestionPayload
+	// recent is the question drawn in the recent games, the newest is the last
+	recent []drawRecord
+	// LogDir is the directory of the event log of every room, empty mean the room is not recorded
+	LogDir string
+	// ReportDir is the directory of the report of every finished game, empty mean no report is written
+	ReportDir     string
+	ReportFormats []string
+}
+
+// drawRecord is the key of the question drawn for the room
+type drawRecord struct {
+	room string
+	keys []string
+}
+
+// maxRecentDraw is total recent games kept for the no repeat rule
+const maxRecentDraw = 100
+
+// NewLobby create the lobby with the default room
+func NewLobby(cfg RoomConfig) (*Lobby, error) {
+	l := &Lobby{
+		rooms:   map[string]*Room{},
+		created: make(chan *Room, 100),
+		Results: NewResultStore(),
+		Ratings: NewRatingStore(),
+	}
+	l.Matchmaker = NewMatchmaker(l)
+	l.bank = cfg.Questions
+
+	cfg.Name = DefaultRoom
+	source := cfg
+	source.Questions = nil
+	if err := l.draw(DefaultRoom, &cfg); err != nil {
+		return nil, err
+	}
+
+	room, err := NewRoom(DefaultRoom, cfg)
+	if err != nil {
+		return nil, err
+	}
+	room.source = source
+
+	l.rooms[room.ID] = room
+	l.created <- room
+
+	return l, nil
+}
+
+// CreateRoom is ...
+func (l *Lobby) CreateRoom(cfg RoomConfig) (*Room, error) {
+	l.mu.Lock()
+	id := fmt.Sprintf("room-%d", l.total+1)
+	if cfg.Name == "" {
+		cfg.Name = id
+	}
+
+	source := cfg
+	if err := l.draw(id, &cfg); err != nil {
+		l.mu.Unlock()
+		return nil, err
+	}
+
+	room, err := NewRoom(id, cfg)
+	if err != nil {
+		l.mu.Unlock()
+		return nil, err
+	}
+	room.source = source
+
+	l.total++
+	l.rooms[id] = room
+	l.mu.Unlock()
+
+	l.created <- room
+
+	return room, nil
+}
+
+// GetRoom return the room, empty id will return the default room
+func (l *Lobby) GetRoom(id string) (*Room, bool) {
+	if id == "" {
+		id = DefaultRoom
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	room, ok := l.rooms[id]
+	return room, ok
+}
+
+// RemoveRoom is ...
+func (l *Lobby) RemoveRoom(id string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.rooms, id)
+}
+
+// ListRooms is ...
+func (l *Lobby) ListRooms() []*Room {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	rooms := []*Room{}
+	for _, room := range l.rooms {
+		rooms = append(rooms, room)
+	}
+
+	sort.Slice(rooms, func(i, j int) bool {
+		return rooms[i].CreatedAt.Before(rooms[j].CreatedAt)
+	})
+
+	return rooms
+}
+
+// ListenRooms run every created room until ctx is done
+func (l *Lobby) ListenRooms(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case room := <-l.created:
+			// the room is stopped after its result is saved
+			roomCtx, stop := context.WithCancel(ctx)
+			l.openLog(room)
+			go room.ListenQueue(roomCtx)
+			go l.listenResult(ctx, room, stop)
+			go addBots(room)
+		}
+	}
+}
+
+// addBots join the bots of the config, the bot is inserted by the queue of the room
+func addBots(room *Room) {
+	if room.source.Bots.Count == 0 {
+		return
+	}
+
+	bots, err := room.AddBots(room.source.Bots)
+	if err != nil {
+		fmt.Printf("room %s: add bots: %v\n", room.ID, err)
+		return
+	}
+
+	fmt.Printf("room %s: %d bots joined\n", room.ID, len(bots))
+}
+
+// openLog record the room when the lobby has the log directory, the room is played without log when it can't be opened
+func (l *Lobby) openLog(room *Room) {
+	if l.LogDir == "" {
+		return
+	}
+
+	log, err := OpenEventLog(l.LogDir, room.ID)
+	if err != nil {
+		fmt.Printf("room %s: event log: %v\n", room.ID, err)
+		return
+	}
+
+	room.SetEventLog(log)
+	fmt.Printf("room %s is recorded to %s\n", room.ID, log.Path())
+}
+
+// listenResult save the result of the room, then the queue and the game of the room is stopped
+func (l *Lobby) listenResult(ctx context.Context, room *Room, stop context.CancelFunc) {
+	defer close(room.saved)
+	defer room.log.Close()
+	defer stop()
+
+	select {
+	case <-ctx.Done():
+	case <-room.Done():
+		// the aborted room has no result
+		if room.Started() {
+			result := room.Result()
+			l.Results.Save(result)
+			l.writeReport(result)
+			if room.rated {
+				l.Ratings.Update(result.Scores)
+			}
+		}
+
+		// the default room is shutdown together with the server
+		if room.ID != DefaultRoom {
+			room.ShutdownClient()
+		}
+
+		if room.temporary {
+			l.RemoveRoom(room.ID)
+		}
+		room.Game.Close()
+	}
+}
+
+// writeReport write the report of the result when the lobby has the report directory
+func (l *Lobby) writeReport(result GameResult) {
+	if l.ReportDir == "" {
+		return
+	}
+
+	formats := l.ReportFormats
+	if formats == nil {
+		formats = ReportFormats
+	}
+
+	paths, err := WriteReport(l.ReportDir, result, formats)
+	for _, path := range paths {
+		fmt.Printf("room %s: report is written to %s\n", result.Room, path)
+	}
+	if err != nil {
+		fmt.Printf("room %s: report: %v\n", result.Room, err)
+	}
+}
+
+// SetBank replace the question bank. The room which is not started and has no own questions
+// get the questions from the new bank, drawn with the same seed. The started game keep its questions
+func (l *Lobby) SetBank(questions []QuestionPayload) {
+	l.mu.Lock()
+	l.bank = questions
+
+	reloads := map[*Room][]QuestionPayload{}
+	for _, room := range l.rooms {
+		if room.source.Questions != nil {
+			continue
+		}
+
+		cfg := room.source
+		if cfg.Draw != nil {
+			draw := *cfg.Draw
+			draw.Seed = room.Seed
+			cfg.Draw = &draw
+		}
+
+		drawn, err := l.drawQuestions(room.ID, cfg)
+		if err != nil {
+			fmt.Printf("room %s keep its questions: %v\n", room.ID, err)
+			continue
+		}
+		reloads[room] = drawn
+	}
+	l.mu.Unlock()
+
+	for room, questions := range reloads {
+		room.PublishQueue(&Event{
+			EventType: ReloadQuestions,
+			Payload:   questions,
+		})
+	}
+}
+
+// drawQuestions is the questions of the room from the bank, it must be called when l.mu is locked
+func (l *Lobby) drawQuestions(room string, cfg RoomConfig) ([]QuestionPayload, error) {
+	questions := cfg.Questions
+	if questions == nil {
+		questions = l.bank
+	}
+	if questions == nil {
+		questions = defaultQuestions()
+	}
+
+	if cfg.Draw == nil {
+		return questions, nil
+	}
+
+	return Draw(questions, *cfg.Draw, l.excluded(room, cfg.Draw.NoRepeat))
+}
+
+// excluded is the key of the question drawn in the last games of the other room,
+// it must be called when l.mu is locked
+func (l *Lobby) excluded(room string, games int) map[string]bool {
+	exclude := map[string]bool{}
+	counted := 0
+	for i := len(l.recent) - 1; i >= 0 && counted < games; i-- {
+		if l.recent[i].room == room {
+			continue
+		}
+
+		counted++
+		for _, key := range l.recent[i].keys {
+			exclude[key] = true
+		}
+	}
+
+	return exclude
+}
+
+// draw set the question of the room from the bank, it must be called when l.mu is locked
+func (l *Lobby) draw(room string, cfg *RoomConfig) error {
+	if cfg.Draw == nil {
+		if cfg.Questions == nil {
+			cfg.Questions = l.bank
+		}
+
+		return nil
+	}
+
+	draw := *cfg.Draw
+	draw.Seed = drawSeed(draw.Seed)
+	cfg.Draw = &draw
+
+	drawn, err := l.drawQuestions(room, *cfg)
+	if err != nil {
+		return err
+	}
+	cfg.Questions = drawn
+
+	keys := []string{}
+	for _, question := range drawn {
+		keys = append(keys, question.key())
+	}
+	l.recent = append(l.recent, drawRecord{room: room, keys: keys})
+	if len(l.recent) > maxRecentDraw {
+		l.recent = l.recent[len(l.recent)-maxRecentDraw:]
+	}
+
+	return nil
+}
@@ -0,0 +1,243 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// DuelRounds is total question of the duel
+	DuelRounds = 3
+	// DuelJoinTimeout is the time for the matched player to join the duel room
+	DuelJoinTimeout = 30 * time.Second
+	// DefaultMatchTimeout is how long the player wait in the queue
+	DefaultMatchTimeout = 30 * time.Second
+
+	// matchWindow is the rating difference allowed when pairing the player,
+	// it grow every second in the queue so nobody wait forever
+	matchWindow       = 100
+	matchWindowGrowth = 50
+	botAccuracy       = 0.6
+)
+
+var (
+	// ErrNoOpponent is returned when the queue is timeout without opponent
+	ErrNoOpponent = errors.New("no opponent found")
+	// ErrAlreadyQueued is returned when the player is already in the queue
+	ErrAlreadyQueued = errors.New("player already in the queue")
+)
+
+type (
+	// Match is the duel room for the player
+	Match struct {
+		Room           string
+		Opponent       string
+		Bot            bool
+		Rating         int
+		OpponentRating int
+	}
+
+	matchResult struct {
+		match Match
+		err   error
+	}
+
+	matchTicket struct {
+		player   string
+		rating   int
+		joinedAt time.Time
+		result   chan matchResult
+	}
+
+	// Matchmaker pair the player in the queue for 1v1 duel.
+	// The player is paired by the rating, the earliest arrival is paired first
+	Matchmaker struct {
+		mu    sync.Mutex
+		lobby *Lobby
+		queue []*matchTicket
+	}
+)
+
+// NewMatchmaker is ...
+func NewMatchmaker(lobby *Lobby) *Matchmaker {
+	return &Matchmaker{
+		lobby: lobby,
+	}
+}
+
+// FindMatch wait in the queue until the opponent is found.
+// When timeout, the bot is the opponent if botFallback is true
+func (m *Matchmaker) FindMatch(ctx context.Context, player string, timeout time.Duration, botFallback bool) (Match, error) {
+	ticket := &matchTicket{
+		player:   player,
+		rating:   m.lobby.Ratings.Get(player),
+		joinedAt: time.Now(),
+		result:   make(chan matchResult, 1),
+	}
+
+	if err := m.enqueue(ticket); err != nil {
+		return Match{}, err
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	// the rating window grow over time, the queue is paired again every second
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case res := <-ticket.result:
+			return res.match, res.err
+		case <-ticker.C:
+			m.pair()
+		case <-ctx.Done():
+			if !m.dequeue(ticket) {
+				res := <-ticket.result
+				return res.match, res.err
+			}
+			return Match{}, ctx.Err()
+		case <-timer.C:
+			if !m.dequeue(ticket) {
+				res := <-ticket.result
+				return res.match, res.err
+			}
+
+			if !botFallback {
+				return Match{}, ErrNoOpponent
+			}
+
+			return m.createDuel(ticket, nil)
+		}
+	}
+}
+
+// Queued return total player in the queue
+func (m *Matchmaker) Queued() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.queue)
+}
+
+func (m *Matchmaker) enqueue(ticket *matchTicket) error {
+	m.mu.Lock()
+	for _, t := range m.queue {
+		if t.player == ticket.player {
+			m.mu.Unlock()
+			return ErrAlreadyQueued
+		}
+	}
+	m.queue = append(m.queue, ticket)
+	m.mu.Unlock()
+
+	m.pair()
+
+	return nil
+}
+
+// dequeue return false when the ticket is already paired
+func (m *Matchmaker) dequeue(ticket *matchTicket) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, t := range m.queue {
+		if t == ticket {
+			m.queue = append(m.queue[:i], m.queue[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// pair the players in the queue, the queue is ordered by arrival
+func (m *Matchmaker) pair() {
+	now := time.Now()
+	pairs := [][2]*matchTicket{}
+
+	m.mu.Lock()
+	for i := 0; i < len(m.queue); i++ {
+		for j := i + 1; j < len(m.queue); j++ {
+			a, b := m.queue[i], m.queue[j]
+			window := matchWindow + matchWindowGrowth*int(now.Sub(a.joinedAt).Seconds())
+			if abs(a.rating-b.rating) > window {
+				continue
+			}
+
+			pairs = append(pairs, [2]*matchTicket{a, b})
+			m.queue = append(m.queue[:j], m.queue[j+1:]...)
+			m.queue = append(m.queue[:i], m.queue[i+1:]...)
+			i--
+			break
+		}
+	}
+	m.mu.Unlock()
+
+	for _, pair := range pairs {
+		match, err := m.createDuel(pair[0], pair[1])
+		pair[0].result <- matchResult{match: match, err: err}
+		pair[1].result <- matchResult{match: Match{
+			Room:           match.Room,
+			Opponent:       pair[0].player,
+			Rating:         pair[1].rating,
+			OpponentRating: pair[0].rating,
+		}, err: err}
+	}
+}
+
+// createDuel create the temporary room, nil opponent mean the bot is the opponent
+func (m *Matchmaker) createDuel(player, opponent *matchTicket) (Match, error) {
+	match := Match{
+		Rating: player.rating,
+	}
+
+	if opponent != nil {
+		match.Opponent = opponent.player
+		match.OpponentRating = opponent.rating
+	} else {
+		match.Bot = true
+		match.Opponent = "bot"
+		if player.player == match.Opponent {
+			match.Opponent = "robot"
+		}
+		match.OpponentRating = DefaultRating
+	}
+
+	room, err := m.lobby.CreateRoom(RoomConfig{
+		Name:       fmt.Sprintf("duel %s vs %s", player.player, match.Opponent),
+		Mode:       ClassicMode,
+		TotalRound: DuelRounds,
+		AutoStart:  2,
+		Temporary:  true,
+		Rated:      !match.Bot,
+		Players:    []string{player.player, match.Opponent},
+	})
+	if err != nil {
+		return Match{}, err
+	}
+	match.Room = room.ID
+
+	// the room is closed when the player doesn't join
+	time.AfterFunc(DuelJoinTimeout, room.Abort)
+
+	if match.Bot {
+		if err := NewBot(match.Opponent, botAccuracy, time.Now().UnixNano()).Join(context.Background(), room); err != nil {
+			return Match{}, err
+		}
+	}
+
+	return match, nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import "fmt"
+
+type (
+	// GameMode is the rule set of the game. It decide the question of each round,
+	// who can answer it and what happen when the round is ended
+	GameMode interface {
+		// Name ...
+		Name() string
+		// Start is called when the game is started with the player in the game
+		Start(players []string)
+		// Next return the question of the round, false will finish the game
+		Next(round int) (QuestionPayload, bool)
+		// CanAnswer return the reason when the player can't answer the question
+		CanAnswer(player string) error
+		// EndRound is called when the round is ended, it return the announcement for all the player
+		EndRound(summary RoundSummary) []string
+	}
+
+	// ranker is implemented by the game mode which rank the player not only by the point
+	ranker interface {
+		Rank(scores []PlayerScore) []PlayerScore
+	}
+
+	// buzzer is implemented by the game mode where the player buzz before answering,
+	// the game mode decide when the round is ended instead of waiting all the answer
+	buzzer interface {
+		StartRound(round int)
+		Buzz(buzz BuzzPayload) (bool, error)
+		Resolve(round int) (BuzzerEvent, bool)
+		Answer(player string, correct bool) (BuzzerEvent, bool, bool)
+		Timeout(round int, holder string) (BuzzerEvent, bool, bool)
+	}
+
+	// RoundSummary is the first answer of each player in the ended round
+	RoundSummary struct {
+		Round   int
+		Answer  string
+		Players []string
+		Answers map[string]string
+		// Skipped is the player who skip the question with the lifeline
+		Skipped map[string]bool
+	}
+
+	// classicMode is the default rule set, every player answer every question
+	classicMode struct {
+		questions []QuestionPayload
+	}
+)
+
+const (
+	// ClassicMode is ...
+	ClassicMode = "classic"
+	// EliminationMode is ...
+	EliminationMode = "elimination"
+	// BuzzerMode is ...
+	BuzzerMode = "buzzer"
+	// PracticeMode is ...
+	PracticeMode = "practice"
+	// AsyncMode is self-paced quiz, it is not played in rounds so it is not a GameMode
+	AsyncMode = "async"
+)
+
+// NewGameMode is ...
+func NewGameMode(name string, questions []QuestionPayload) (GameMode, error) {
+	switch name {
+	case ClassicMode, "":
+		return &classicMode{questions: questions}, nil
+	case EliminationMode:
+		return newEliminationMode(questions), nil
+	case BuzzerMode:
+		return newBuzzerMode(questions), nil
+	case PracticeMode:
+		return newPracticeMode(questions), nil
+	default:
+		return nil, fmt.Errorf("game mode %s not found", name)
+	}
+}
+
+func (m *classicMode) Name() string { return ClassicMode }
+
+func (m *classicMode) Start([]string) {}
+
+func (m *classicMode) Next(round int) (QuestionPayload, bool) {
+	if round >= len(m.questions) {
+		return QuestionPayload{}, false
+	}
+
+	return m.questions[round], true
+}
+
+func (m *classicMode) CanAnswer(string) error { return nil }
+
+func (m *classicMode) EndRound(RoundSummary) []string { return nil }
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"fmt"
+	"sync"
+)
+
+// practiceRetries is how many times the question answered wrong is asked again
+const practiceRetries = 2
+
+// practiceMode is solo practice rule set. The question answered wrong
+// or not answered is asked again after the other questions
+type practiceMode struct {
+	mu    sync.RWMutex
+	queue []QuestionPayload
+	// retries is total retry of each question
+	retries map[string]int
+}
+
+func newPracticeMode(questions []QuestionPayload) *practiceMode {
+	return &practiceMode{
+		queue:   append([]QuestionPayload{}, questions...),
+		retries: map[string]int{},
+	}
+}
+
+func (m *practiceMode) Name() string { return PracticeMode }
+
+func (m *practiceMode) Start([]string) {}
+
+func (m *practiceMode) Next(round int) (QuestionPayload, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if round >= len(m.queue) {
+		return QuestionPayload{}, false
+	}
+
+	return m.queue[round], true
+}
+
+func (m *practiceMode) CanAnswer(string) error { return nil }
+
+func (m *practiceMode) EndRound(summary RoundSummary) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	wrong := false
+	for _, player := range summary.Players {
+		answer, ok := summary.Answers[player]
+		if (!ok || answer != summary.Answer) && !summary.Skipped[player] {
+			wrong = true
+		}
+	}
+
+	if !wrong {
+		return nil
+	}
+
+	question := m.queue[summary.Round-1]
+	if m.retries[question.question] >= practiceRetries {
+		return []string{"no more retry for this question"}
+	}
+
+	m.retries[question.question]++
+	m.queue = append(m.queue, question)
+
+	return []string{fmt.Sprintf("you will retry this question later (%d/%d)", m.retries[question.question], practiceRetries)}
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Option is the choice of the question, the player answer with the key
+type Option struct {
+	Key  string
+	Text string
+}
+
+// trueFalseKeys is the key of the question without options
+var trueFalseKeys = []string{"Y", "N"}
+
+// keys is Y and N for the true or false question, A, B, C and so on for the multiple-choice question
+func (q QuestionPayload) keys() []string {
+	if len(q.options) == 0 {
+		return trueFalseKeys
+	}
+
+	keys := []string{}
+	for i := range q.options {
+		keys = append(keys, string(rune('A'+i)))
+	}
+
+	return keys
+}
+
+// choices is the option sent to the player, it is empty for the true or false question
+func (q QuestionPayload) choices() []Option {
+	choices := []Option{}
+	for i, key := range q.keys() {
+		if i < len(q.options) {
+			choices = append(choices, Option{Key: key, Text: q.options[i]})
+		}
+	}
+
+	return choices
+}
+
+// validAnswer return the reason when the answer is not one of the key
+func (q QuestionPayload) validAnswer(answer string) error {
+	for _, key := range q.keys() {
+		if key == answer {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("only accept (%s) when game is started", strings.Join(q.keys(), "/"))
+}
+
+// wrongKeys is the key of the wrong options
+func (q QuestionPayload) wrongKeys() []string {
+	keys := []string{}
+	for _, key := range q.keys() {
+		if key != q.answer {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// level is the difficulty of the question, medium when it is not set
+func (q QuestionPayload) level() int {
+	if q.difficulty == 0 {
+		return Medium
+	}
+
+	return q.difficulty
+}
+
+// key identify the question, the question text is used when the id is empty
+func (q QuestionPayload) key() string {
+	if q.id != "" {
+		return q.id
+	}
+
+	return q.question
+}
+
+// timeOf is the time to answer the question, the round time is used when the question has no time limit
+func (q QuestionPayload) timeOf(round time.Duration) time.Duration {
+	if q.timeLimit > 0 {
+		return q.timeLimit
+	}
+
+	return round
+}
+
+// weightOf is the multiplier of the point, 1 when it is not set
+func (q QuestionPayload) weightOf() int {
+	if q.weight == 0 {
+		return 1
+	}
+
+	return q.weight
+}
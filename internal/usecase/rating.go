@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"math"
+	"sync"
+)
+
+const (
+	// DefaultRating is the rating of the new player
+	DefaultRating = 1000
+	// ratingFactor is the maximum rating change of a game
+	ratingFactor = 32
+)
+
+// RatingStore is in memory elo rating of the player
+type RatingStore struct {
+	mu      sync.RWMutex
+	ratings map[string]int
+}
+
+// NewRatingStore is ...
+func NewRatingStore() *RatingStore {
+	return &RatingStore{
+		ratings: map[string]int{},
+	}
+}
+
+// Get return the default rating when the player is not rated yet
+func (s *RatingStore) Get(player string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if rating, ok := s.ratings[player]; ok {
+		return rating
+	}
+
+	return DefaultRating
+}
+
+// Update apply the result of the game, every pair of player in the game is a match. The bot is not rated
+func (s *RatingStore) Update(result []PlayerScore) {
+	scores := []PlayerScore{}
+	for _, score := range result {
+		if !score.Bot {
+			scores = append(scores, score)
+		}
+	}
+
+	if len(scores) < 2 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current := map[string]int{}
+	for _, score := range scores {
+		current[score.Name] = DefaultRating
+		if rating, ok := s.ratings[score.Name]; ok {
+			current[score.Name] = rating
+		}
+	}
+
+	for _, a := range scores {
+		change := 0.0
+		for _, b := range scores {
+			if a.Name == b.Name {
+				continue
+			}
+
+			actual := 0.5
+			if a.Point > b.Point {
+				actual = 1
+			} else if a.Point < b.Point {
+				actual = 0
+			}
+
+			expected := 1 / (1 + math.Pow(10, float64(current[b.Name]-current[a.Name])/400))
+			change += ratingFactor * (actual - expected)
+		}
+
+		s.ratings[a.Name] = current[a.Name] + int(math.Round(change/float64(len(scores)-1)))
+	}
+}
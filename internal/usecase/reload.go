@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultReloadInterval is how often the question bank is checked for changes
+const DefaultReloadInterval = 2 * time.Second
+
+type (
+	// BankStatus is the result of the last load of the question bank
+	BankStatus struct {
+		Path string
+		Name string
+		// Version is increased on every successful load
+		Version   int
+		Questions int
+		LoadedAt  time.Time
+		// Errors is the reason of the last failed reload, it is empty when the last reload succeed
+		Errors   []string
+		FailedAt time.Time
+	}
+
+	// BankReloader load the question bank again when the files are changed.
+	// The bank is replaced only when every question is valid, the failed reload keep the old bank
+	BankReloader struct {
+		mu sync.RWMutex
+		// reload serialize the reload, so the last loaded bank is the last one sent to OnReload
+		reload      sync.Mutex
+		path        string
+		questions   []QuestionPayload
+		status      BankStatus
+		fingerprint string
+		// OnReload is called with the new questions after the successful reload
+		OnReload func(questions []QuestionPayload)
+	}
+)
+
+// NewBankReloader load the bank for the first time, it return error when the bank is invalid
+func NewBankReloader(path string) (*BankReloader, error) {
+	r := &BankReloader{
+		path:   path,
+		status: BankStatus{Path: path},
+	}
+
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Questions is the question of the current bank
+func (r *BankReloader) Questions() []QuestionPayload {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.questions
+}
+
+// Status is ...
+func (r *BankReloader) Status() BankStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	status := r.status
+	status.Errors = append([]string{}, r.status.Errors...)
+
+	return status
+}
+
+// Reload load the bank, the lint error fail the reload
+func (r *BankReloader) Reload() error {
+	r.reload.Lock()
+	defer r.reload.Unlock()
+
+	fingerprint, _ := bankFingerprint(r.path)
+
+	questions, name, err := loadValidBank(r.path)
+
+	r.mu.Lock()
+	r.fingerprint = fingerprint
+	if err != nil {
+		r.status.Errors = strings.Split(err.Error(), "\n")
+		r.status.FailedAt = time.Now()
+		r.mu.Unlock()
+
+		return err
+	}
+
+	r.questions = questions
+	r.status.Name = name
+	r.status.Version++
+	r.status.Questions = len(questions)
+	r.status.LoadedAt = time.Now()
+	r.status.Errors = nil
+	r.status.FailedAt = time.Time{}
+	onReload := r.OnReload
+	r.mu.Unlock()
+
+	if onReload != nil {
+		onReload(questions)
+	}
+
+	return nil
+}
+
+// Watch reload the bank when the files are changed until ctx is done
+func (r *BankReloader) Watch(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultReloadInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// the missing bank is reported once until it is changed again
+			fingerprint, _ := bankFingerprint(r.path)
+			r.mu.RLock()
+			changed := fingerprint != r.fingerprint
+			r.mu.RUnlock()
+
+			if !changed {
+				continue
+			}
+
+			if err := r.Reload(); err != nil {
+				fmt.Printf("reload question bank %s failed, the old bank is kept: %v\n", r.path, err)
+				continue
+			}
+
+			fmt.Printf("question bank %s is reloaded, version %d\n", r.path, r.Status().Version)
+		}
+	}
+}
+
+func loadValidBank(path string) ([]QuestionPayload, string, error) {
+	bank, err := LoadBank(path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	errs := []error{}
+	for _, issue := range bank.Lint() {
+		if issue.Severity == LintError {
+			errs = append(errs, errors.New(issue.String()))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, "", errors.Join(errs...)
+	}
+
+	questions, err := bank.Payloads()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return questions, bank.Name, nil
+}
+
+// bankFingerprint change when a file of the bank is added, removed or modified
+func bankFingerprint(path string) (string, error) {
+	files := []string{path}
+	if info, err := os.Stat(path); err != nil {
+		return "", err
+	} else if info.IsDir() {
+		if files, err = filepath.Glob(filepath.Join(path, "*.json")); err != nil {
+			return "", err
+		}
+		sort.Strings(files)
+	}
+
+	fingerprint := strings.Builder{}
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return "", err
+		}
+
+		fmt.Fprintf(&fingerprint, "%s:%d:%d;", file, info.Size(), info.ModTime().UnixNano())
+	}
+
+	return fingerprint.String(), nil
+}
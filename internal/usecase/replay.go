@@ -0,0 +1,387 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"time"
+
+	quiz "github.com/elangreza14/grpc-quiz/proto"
+)
+
+// replayTimeout fail the replay when the game emit nothing, the replayed game never wait in real time
+const replayTimeout = 5 * time.Second
+
+type (
+	// Replay run the game of the event log again with the manual clock.
+	// The event is published at the time it is recorded, and every state of the game must be the same as the log
+	Replay struct {
+		Room *Room
+		// Speed is the pace of the replay, 1 is the recorded pace and 2 is twice faster. zero is as fast as possible
+		Speed float64
+
+		entries []LogEntry
+		clock   *ManualClock
+		log     *EventLog
+	}
+
+	// ReplayResult is the final scores of the replay and the log
+	ReplayResult struct {
+		Scores []PlayerScore
+		// Recorded is nil when the log has no final scores, e.g. the server is stopped before the game is finished
+		Recorded []PlayerScore
+	}
+)
+
+var eventTypes = func() map[string]eventType {
+	types := map[string]eventType{}
+	for evt, name := range eventNames {
+		types[name] = evt
+	}
+
+	return types
+}()
+
+// LoadEventLog read the event log file
+func LoadEventLog(path string) ([]LogEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	entries, err := ReadEventLog(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return entries, nil
+}
+
+// ReadEventLog read the line of the log until EOF
+func ReadEventLog(r io.Reader) ([]LogEntry, error) {
+	dec := json.NewDecoder(r)
+	entries := []LogEntry{}
+	for {
+		entry := LogEntry{}
+		if err := dec.Decode(&entry); err != nil {
+			if errors.Is(err, io.EOF) {
+				return entries, nil
+			}
+			return nil, fmt.Errorf("line %d: %w", len(entries)+1, err)
+		}
+
+		entries = append(entries, entry)
+	}
+}
+
+// NewReplay create the room of the log again, the first line of the log must be the room
+func NewReplay(entries []LogEntry) (*Replay, error) {
+	if len(entries) == 0 || entries[0].Kind != logRoom {
+		return nil, errors.New("the log has no room")
+	}
+
+	header := roomHeader{}
+	if err := json.Unmarshal(entries[0].Payload, &header); err != nil {
+		return nil, fmt.Errorf("line 1: %w", err)
+	}
+
+	cfg := header.Config
+	if cfg.Mode == AsyncMode {
+		return nil, fmt.Errorf("replay is not supported in %s mode", AsyncMode)
+	}
+
+	questions, err := toPayloads(header.Questions)
+	if err != nil {
+		return nil, fmt.Errorf("line 1: %w", err)
+	}
+	cfg.Questions = questions
+
+	clock := NewManualClock(header.StartedAt)
+	cfg.Clock = clock
+
+	room, err := NewRoom(header.ID, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	log := newMemoryLog(clock)
+	room.log = log
+
+	return &Replay{
+		Room:    room,
+		entries: entries[1:],
+		clock:   clock,
+		log:     log,
+	}, nil
+}
+
+// Lobby hold the replayed room only, so the spectator can watch the replay by the room id or as the default room
+func (p *Replay) Lobby() *Lobby {
+	return &Lobby{
+		rooms: map[string]*Room{
+			p.Room.ID:   p.Room,
+			DefaultRoom: p.Room,
+		},
+		Results: NewResultStore(),
+		Ratings: NewRatingStore(),
+	}
+}
+
+// Run publish every recorded event to the room and check the state of the game against the log.
+// It return error when the replay is different from the log
+func (p *Replay) Run(ctx context.Context) (ReplayResult, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go p.Room.ListenQueue(ctx)
+
+	start := p.clock.Now()
+	var (
+		recorded []PlayerScore
+		states   int
+		last     time.Duration
+	)
+
+	for i, entry := range p.entries {
+		// the first line is the room
+		line := i + 2
+
+		if err := p.wait(ctx, entry.At-last); err != nil {
+			return ReplayResult{}, err
+		}
+		last = entry.At
+
+		// the due timer of the game is fired before the entry
+		if elapsed := p.clock.Now().Sub(start); entry.At > elapsed {
+			p.clock.Advance(entry.At - elapsed)
+		}
+
+		switch entry.Kind {
+		case logEvent:
+			evt, err := decodeEvent(entry)
+			if err != nil {
+				return ReplayResult{}, fmt.Errorf("line %d: %w", line, err)
+			}
+
+			if evt.EventType == InsertPlayer {
+				p.join(ctx, entry)
+			}
+			p.Room.PublishQueue(evt)
+		case logControl:
+			payload := controlPayload{}
+			if err := json.Unmarshal(entry.Payload, &payload); err != nil {
+				return ReplayResult{}, fmt.Errorf("line %d: %w", line, err)
+			}
+
+			// the rejected action is recorded too, the replay reject it the same way
+			_ = p.Room.Control(payload.Action, payload.Extend)
+		case logRemove:
+			var player string
+			if err := json.Unmarshal(entry.Payload, &player); err != nil {
+				return ReplayResult{}, fmt.Errorf("line %d: %w", line, err)
+			}
+
+			p.Room.RemovePlayer(player)
+		case logState:
+			got, err := p.state(ctx, states)
+			if err != nil {
+				return ReplayResult{}, fmt.Errorf("line %d: %w", line, err)
+			}
+			states++
+
+			if got.State == nil || entry.State == nil || *got.State != *entry.State || got.Type != entry.Type {
+				return ReplayResult{}, fmt.Errorf("line %d: the replay emit %s, the log has %s", line, describeEntry(got), describeEntry(entry))
+			}
+		case logResult:
+			if err := json.Unmarshal(entry.Payload, &recorded); err != nil {
+				return ReplayResult{}, fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+	}
+
+	result := ReplayResult{Recorded: recorded}
+
+	// the room is done after the last state, the unfinished game is replayed until the last line
+	if recorded != nil {
+		select {
+		case <-ctx.Done():
+			return ReplayResult{}, ctx.Err()
+		case <-p.Room.Done():
+		case <-time.After(replayTimeout):
+			return ReplayResult{}, errors.New("the replayed game is not finished")
+		}
+	}
+
+	result.Scores = p.Room.Scores()
+	if recorded != nil && !sameScores(result.Scores, recorded) {
+		return result, errors.New("the final scores of the replay are different from the log")
+	}
+
+	return result, nil
+}
+
+// wait the recorded gap between the entries, it is shortened by the speed
+func (p *Replay) wait(ctx context.Context, gap time.Duration) error {
+	if p.Speed <= 0 || gap <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(time.Duration(float64(gap) / p.Speed))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// state wait until the game emit the state, the game emit the state in its own goroutine
+func (p *Replay) state(ctx context.Context, i int) (LogEntry, error) {
+	deadline := time.Now().Add(replayTimeout)
+	for {
+		if entry, ok := p.log.stateAt(i); ok {
+			return entry, nil
+		}
+
+		if time.Now().After(deadline) {
+			return LogEntry{}, fmt.Errorf("the replay emit no state after %s", replayTimeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return LogEntry{}, ctx.Err()
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+// join the player like the bot, nobody read the channel of the replayed player
+func (p *Replay) join(ctx context.Context, entry LogEntry) {
+	var player string
+	_ = json.Unmarshal(entry.Payload, &player)
+
+	if entry.Bot {
+		p.Room.bots.Store(player, true)
+	}
+
+	if p.Room.Teams != nil && entry.Team != "" {
+		if _, err := p.Room.Teams.Join(player, entry.Team); err != nil {
+			fmt.Printf("replay: player %s can't join team %s: %v\n", player, entry.Team, err)
+		}
+	}
+
+	ch := make(chan *quiz.StreamResponse, 100)
+	if _, loaded := p.Room.players.LoadOrStore(player, ch); loaded {
+		return
+	}
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-p.Room.Done():
+				return
+			case <-ch:
+			}
+		}
+	}()
+}
+
+// decodeEvent is the event of the line, the payload is decoded by the event type
+func decodeEvent(entry LogEntry) (*Event, error) {
+	evt, ok := eventTypes[entry.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown event %q", entry.Type)
+	}
+
+	var (
+		payload any
+		err     error
+	)
+	switch evt {
+	case InsertPlayer, Broadcast, RequestHint:
+		payload, err = decodePayload[string](entry)
+	case BroadcastPersonal:
+		payload, err = decodePayload[BroadcastPersonalPayload](entry)
+	case SubmitAnswer:
+		payload, err = decodePayload[SubmitAnswerPayload](entry)
+	case TeamChat:
+		payload, err = decodePayload[TeamChatPayload](entry)
+	case Buzz:
+		payload, err = decodePayload[BuzzPayload](entry)
+	case UseLifeline:
+		payload, err = decodePayload[LifelinePayload](entry)
+	case ReloadQuestions:
+		var questions []Question
+		if questions, err = decodePayload[[]Question](entry); err == nil {
+			payload, err = toPayloads(questions)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("event %s: %w", entry.Type, err)
+	}
+
+	return &Event{EventType: evt, Payload: payload}, nil
+}
+
+func decodePayload[T any](entry LogEntry) (T, error) {
+	var payload T
+	err := json.Unmarshal(entry.Payload, &payload)
+
+	return payload, err
+}
+
+func toPayloads(questions []Question) ([]QuestionPayload, error) {
+	if questions == nil {
+		return nil, nil
+	}
+
+	payloads := []QuestionPayload{}
+	for i, question := range questions {
+		payload, err := question.payload()
+		if err != nil {
+			return nil, fmt.Errorf("question %d %q: %w", i+1, question.Question, err)
+		}
+		payloads = append(payloads, payload)
+	}
+
+	return payloads, nil
+}
+
+func describeEntry(entry LogEntry) string {
+	if entry.State == nil {
+		return entry.Kind
+	}
+
+	name := map[State]string{Waiting: "waiting", OnProgress: "on progress", Done: "done"}[*entry.State]
+	if entry.Type == "" {
+		return name
+	}
+
+	return fmt.Sprintf("%s %s", name, entry.Type)
+}
+
+// sameScores compare the point of each player, the player with the same point is in any order
+func sameScores(a, b []PlayerScore) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	sorted := func(scores []PlayerScore) []PlayerScore {
+		scores = append([]PlayerScore{}, scores...)
+		sort.Slice(scores, func(i, j int) bool { return scores[i].Name < scores[j].Name })
+
+		return scores
+	}
+
+	return reflect.DeepEqual(sorted(a), sorted(b))
+}
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	quiz "github.com/elangreza14/grpc-quiz/proto"
+)
+
+// recordStart is the time of the manual clock of the recorded room
+var recordStart = time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+// recordGame play two rounds with the manual clock, the log is opened with the wall clock as the file log
+// and it is measured by the clock of the room
+func recordGame(t *testing.T) []byte {
+	t.Helper()
+
+	clock := NewManualClock(recordStart)
+	room, err := NewRoom("room-1", RoomConfig{
+		Questions: []QuestionPayload{
+			{question: "q1", answer: "Y"},
+			{question: "q2", answer: "N"},
+		},
+		Clock: clock,
+	})
+	if err != nil {
+		t.Fatalf("NewRoom() error = %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	log := &EventLog{enc: json.NewEncoder(buf), clock: RealClock, start: time.Now()}
+	room.SetEventLog(log)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go room.ListenQueue(ctx)
+
+	events, stop := room.Watch()
+	defer stop()
+
+	// wait until the room broadcast the event, the event is recorded when the room process it
+	wait := func(match func(*quiz.StreamResponse) bool) {
+		t.Helper()
+		for {
+			select {
+			case res := <-events:
+				if match(res) {
+					return
+				}
+			case <-time.After(idleTimeout):
+				t.Fatalf("no event after %s", idleTimeout)
+			}
+		}
+	}
+	question := func(res *quiz.StreamResponse) bool { return res.GetQuestion() != nil }
+	answered := func(total int32) func(*quiz.StreamResponse) bool {
+		return func(res *quiz.StreamResponse) bool { return res.GetAnswerProgress().GetTotalAnswer() == total }
+	}
+
+	publish := func(evt eventType, payload any) {
+		room.PublishQueue(&Event{EventType: evt, Payload: payload})
+	}
+
+	for _, player := range []string{"ann", "bob"} {
+		room.players.Store(player, make(chan *quiz.StreamResponse, 100))
+		publish(InsertPlayer, player)
+	}
+	publish(StartGame, nil)
+
+	wait(question)
+	clock.Advance(2 * time.Second)
+	publish(SubmitAnswer, SubmitAnswerPayload{Name: "ann", Answer: "Y"})
+	publish(SubmitAnswer, SubmitAnswerPayload{Name: "bob", Answer: "N"})
+
+	// only ann answer the second round, the round is ended by the timeout
+	wait(question)
+	clock.Advance(3 * time.Second)
+	publish(SubmitAnswer, SubmitAnswerPayload{Name: "ann", Answer: "N"})
+	wait(answered(1))
+	clock.Advance(DefaultTimePerRound)
+
+	select {
+	case <-room.Done():
+	case <-time.After(idleTimeout):
+		t.Fatalf("the game is not finished after %s", idleTimeout)
+	}
+
+	return buf.Bytes()
+}
+
+func TestReplay(t *testing.T) {
+	recorded := recordGame(t)
+
+	tests := []struct {
+		name    string
+		log     string
+		wantErr string
+	}{
+		{
+			name: "the replay reach the same final scores",
+			log:  string(recorded),
+		},
+		{
+			name:    "the changed answer is detected",
+			log:     strings.Replace(string(recorded), `{"Name":"bob","Answer":"N"}`, `{"Name":"bob","Answer":"Y"}`, 1),
+			wantErr: "the final scores of the replay are different from the log",
+		},
+		{
+			name:    "the different state is detected",
+			log:     strings.Replace(string(recorded), `"type":"AnswerProgress"`, `"type":"RoundResult"`, 1),
+			wantErr: "the replay emit on progress AnswerProgress, the log has on progress RoundResult",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := ReadEventLog(strings.NewReader(tt.log))
+			if err != nil {
+				t.Fatalf("ReadEventLog() error = %v", err)
+			}
+
+			replay, err := NewReplay(entries)
+			if err != nil {
+				t.Fatalf("NewReplay() error = %v", err)
+			}
+
+			// the clock of the replay start at the clock of the recorded room, the last line is after the 2 rounds
+			if !replay.clock.Now().Equal(recordStart) {
+				t.Errorf("replay start = %s, want %s", replay.clock.Now(), recordStart)
+			}
+			if last, want := entries[len(entries)-1].At, 5*time.Second+DefaultTimePerRound; last != want {
+				t.Errorf("the last line at %s, want %s", last, want)
+			}
+
+			result, err := replay.Run(context.Background())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Run() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			got := map[string]int{}
+			for _, score := range result.Scores {
+				got[score.Name] = score.Point
+			}
+			if got["ann"] != 2 || got["bob"] != 0 {
+				t.Errorf("scores = %v, want ann 2 and bob 0", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,350 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// the format of the report
+const (
+	ReportCSV      = "csv"
+	ReportJSON     = "json"
+	ReportMarkdown = "md"
+)
+
+// ReportFormats is every format of the report
+var ReportFormats = []string{ReportCSV, ReportJSON, ReportMarkdown}
+
+type (
+	// Report is the result of the finished game for the host, it is exported as csv, json or markdown
+	Report struct {
+		Room       string           `json:"room"`
+		FinishedAt time.Time        `json:"finished_at"`
+		Ranking    []ReportRank     `json:"ranking"`
+		Teams      []ReportTeam     `json:"teams,omitempty"`
+		Questions  []ReportQuestion `json:"questions"`
+		Answers    []ReportAnswer   `json:"answers"`
+	}
+
+	// ReportRank is the final position of the player, the player with the same point share the rank
+	ReportRank struct {
+		Rank       int    `json:"rank"`
+		Player     string `json:"player"`
+		Point      int    `json:"point"`
+		Bot        bool   `json:"bot,omitempty"`
+		Eliminated bool   `json:"eliminated,omitempty"`
+	}
+
+	// ReportTeam is the final point of the team
+	ReportTeam struct {
+		Team  string  `json:"team"`
+		Point float64 `json:"point"`
+	}
+
+	// ReportQuestion is the accuracy of the question, the accuracy is the correct answer over the players who can answer
+	ReportQuestion struct {
+		Round    int     `json:"round"`
+		Question string  `json:"question"`
+		Answer   string  `json:"answer"`
+		Players  int     `json:"players"`
+		Answered int     `json:"answered"`
+		Correct  int     `json:"correct"`
+		Accuracy float64 `json:"accuracy"`
+	}
+
+	// ReportAnswer is the answer of the player to the question, the latency is zero when the player doesn't answer
+	ReportAnswer struct {
+		Round    int     `json:"round"`
+		Question string  `json:"question"`
+		Player   string  `json:"player"`
+		Answer   string  `json:"answer"`
+		Correct  bool    `json:"correct"`
+		Latency  float64 `json:"latency_seconds"`
+	}
+
+	// ReportFile is the exported report, the csv report is split into a file for each table
+	ReportFile struct {
+		Name string
+		Data []byte
+	}
+)
+
+// ParseReportFormats parse comma separated formats, e.g. csv,md. empty formats is every format
+func ParseReportFormats(s string) ([]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return ReportFormats, nil
+	}
+
+	formats := []string{}
+	for _, format := range strings.Split(s, ",") {
+		format = strings.ToLower(strings.TrimSpace(format))
+		if format == "markdown" {
+			format = ReportMarkdown
+		}
+
+		if !contains(ReportFormats, format) {
+			return nil, fmt.Errorf("report format %q must be one of %s", format, strings.Join(ReportFormats, ", "))
+		}
+
+		if !contains(formats, format) {
+			formats = append(formats, format)
+		}
+	}
+
+	return formats, nil
+}
+
+// NewReport is the report of the result, the question is in the order of the answers
+func NewReport(result GameResult) Report {
+	report := Report{
+		Room:       result.Room,
+		FinishedAt: result.FinishedAt,
+		Ranking:    []ReportRank{},
+		Questions:  []ReportQuestion{},
+		Answers:    []ReportAnswer{},
+	}
+
+	for i, score := range result.Scores {
+		rank := i + 1
+		if i > 0 {
+			prev := result.Scores[i-1]
+			if prev.Point == score.Point && prev.Eliminated == score.Eliminated {
+				rank = report.Ranking[i-1].Rank
+			}
+		}
+
+		report.Ranking = append(report.Ranking, ReportRank{
+			Rank:       rank,
+			Player:     score.Name,
+			Point:      score.Point,
+			Bot:        score.Bot,
+			Eliminated: score.Eliminated,
+		})
+	}
+
+	for _, team := range result.Teams {
+		report.Teams = append(report.Teams, ReportTeam{Team: team.Name, Point: team.Point})
+	}
+
+	// in the self-paced adaptive quiz the player get a different question in the same round
+	type questionKey struct {
+		round    int
+		question string
+	}
+	questions := map[questionKey]int{}
+	for _, answer := range result.Answers {
+		report.Answers = append(report.Answers, ReportAnswer{
+			Round:    answer.Round,
+			Question: answer.Question,
+			Player:   answer.Player,
+			Answer:   answer.Answer,
+			Correct:  answer.Correct,
+			Latency:  answer.Latency.Seconds(),
+		})
+
+		key := questionKey{round: answer.Round, question: answer.Question}
+		i, ok := questions[key]
+		if !ok {
+			i = len(report.Questions)
+			questions[key] = i
+			report.Questions = append(report.Questions, ReportQuestion{
+				Round:    answer.Round,
+				Question: answer.Question,
+				Answer:   answer.CorrectAnswer,
+			})
+		}
+
+		question := &report.Questions[i]
+		question.Players++
+		if answer.Answer != "" {
+			question.Answered++
+		}
+		if answer.Correct {
+			question.Correct++
+		}
+		question.Accuracy = float64(question.Correct) / float64(question.Players)
+	}
+
+	return report
+}
+
+// Files is the report in the format, the file is named by the room and the finished time
+func (r Report) Files(format string) ([]ReportFile, error) {
+	name := fmt.Sprintf("%s-%s", r.Room, r.FinishedAt.Format("20060102-150405"))
+
+	switch format {
+	case ReportJSON:
+		data, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		return []ReportFile{{Name: name + ".json", Data: append(data, '\n')}}, nil
+	case ReportCSV:
+		tables := []struct {
+			name string
+			rows [][]string
+		}{
+			{"ranking", r.rankingRows()},
+			{"questions", r.questionRows()},
+			{"answers", r.answerRows()},
+		}
+
+		files := []ReportFile{}
+		for _, table := range tables {
+			buf := &bytes.Buffer{}
+			if err := csv.NewWriter(buf).WriteAll(table.rows); err != nil {
+				return nil, err
+			}
+
+			files = append(files, ReportFile{Name: fmt.Sprintf("%s-%s.csv", name, table.name), Data: buf.Bytes()})
+		}
+
+		return files, nil
+	case ReportMarkdown:
+		return []ReportFile{{Name: name + ".md", Data: r.markdown()}}, nil
+	default:
+		return nil, fmt.Errorf("report format %q must be one of %s", format, strings.Join(ReportFormats, ", "))
+	}
+}
+
+// WriteReport write the report of the result in every format to the directory, it return the path of the files
+func WriteReport(dir string, result GameResult, formats []string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	report := NewReport(result)
+	paths := []string{}
+	for _, format := range formats {
+		files, err := report.Files(format)
+		if err != nil {
+			return paths, err
+		}
+
+		for _, file := range files {
+			path := filepath.Join(dir, file.Name)
+			if err := os.WriteFile(path, file.Data, 0o644); err != nil {
+				return paths, err
+			}
+			paths = append(paths, path)
+		}
+	}
+
+	return paths, nil
+}
+
+func (r Report) rankingRows() [][]string {
+	rows := [][]string{{"rank", "player", "point", "bot", "eliminated"}}
+	for _, rank := range r.Ranking {
+		rows = append(rows, []string{
+			strconv.Itoa(rank.Rank),
+			rank.Player,
+			strconv.Itoa(rank.Point),
+			strconv.FormatBool(rank.Bot),
+			strconv.FormatBool(rank.Eliminated),
+		})
+	}
+
+	return rows
+}
+
+func (r Report) questionRows() [][]string {
+	rows := [][]string{{"round", "question", "answer", "players", "answered", "correct", "accuracy"}}
+	for _, question := range r.Questions {
+		rows = append(rows, []string{
+			strconv.Itoa(question.Round),
+			question.Question,
+			question.Answer,
+			strconv.Itoa(question.Players),
+			strconv.Itoa(question.Answered),
+			strconv.Itoa(question.Correct),
+			strconv.FormatFloat(question.Accuracy, 'f', 2, 64),
+		})
+	}
+
+	return rows
+}
+
+// answerRows leave the answer and the latency empty when the player doesn't answer
+func (r Report) answerRows() [][]string {
+	rows := [][]string{{"round", "question", "player", "answer", "correct", "latency_seconds"}}
+	for _, answer := range r.Answers {
+		latency := ""
+		if answer.Answer != "" {
+			latency = strconv.FormatFloat(answer.Latency, 'f', 3, 64)
+		}
+
+		rows = append(rows, []string{
+			strconv.Itoa(answer.Round),
+			answer.Question,
+			answer.Player,
+			answer.Answer,
+			strconv.FormatBool(answer.Correct),
+			latency,
+		})
+	}
+
+	return rows
+}
+
+// markdown is the summary for the wiki, every table can be pasted on its own
+func (r Report) markdown() []byte {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "# Quiz report: %s\n\n", markdownCell(r.Room))
+	fmt.Fprintf(buf, "Finished at %s\n\n", r.FinishedAt.Format(time.DateTime))
+
+	fmt.Fprintf(buf, "## Ranking\n\n| Rank | Player | Point |\n| ---: | --- | ---: |\n")
+	for _, rank := range r.Ranking {
+		player := markdownCell(rank.Player)
+		if rank.Bot {
+			player += " (bot)"
+		}
+		if rank.Eliminated {
+			player += " (eliminated)"
+		}
+		fmt.Fprintf(buf, "| %d | %s | %d |\n", rank.Rank, player, rank.Point)
+	}
+
+	if len(r.Teams) > 0 {
+		fmt.Fprintf(buf, "\n## Teams\n\n| Team | Point |\n| --- | ---: |\n")
+		for _, team := range r.Teams {
+			fmt.Fprintf(buf, "| %s | %.2f |\n", markdownCell(team.Team), team.Point)
+		}
+	}
+
+	fmt.Fprintf(buf, "\n## Questions\n\n| Round | Question | Answer | Correct | Accuracy |\n| ---: | --- | --- | ---: | ---: |\n")
+	for _, question := range r.Questions {
+		fmt.Fprintf(buf, "| %d | %s | %s | %d/%d | %.0f%% |\n", question.Round, markdownCell(question.Question),
+			question.Answer, question.Correct, question.Players, question.Accuracy*100)
+	}
+
+	fmt.Fprintf(buf, "\n## Answers\n\n| Round | Player | Answer | Correct | Latency |\n| ---: | --- | --- | --- | ---: |\n")
+	for _, answer := range r.Answers {
+		given, correct, latency := "-", "no", "-"
+		if answer.Answer != "" {
+			given = answer.Answer
+			latency = fmt.Sprintf("%.1fs", answer.Latency)
+		}
+		if answer.Correct {
+			correct = "yes"
+		}
+		fmt.Fprintf(buf, "| %d | %s | %s | %s | %s |\n", answer.Round, markdownCell(answer.Player), given, correct, latency)
+	}
+
+	return buf.Bytes()
+}
+
+// markdownCell escape the pipe and the new line, so the text stay in the cell of the table
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReportFiles(t *testing.T) {
+	result := GameResult{
+		Room:       "room-1",
+		FinishedAt: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+		Scores: []PlayerScore{
+			{Name: "ann", Point: 20},
+			{Name: "bot-1", Point: 10, Bot: true},
+			{Name: "bob", Point: 10},
+		},
+		Answers: []AnswerRecord{
+			{Round: 1, Question: "1 | 1", CorrectAnswer: "Y", Player: "ann", Answer: "Y", Correct: true, Latency: 1500 * time.Millisecond},
+			{Round: 1, Question: "1 | 1", CorrectAnswer: "Y", Player: "bob", Answer: "N", Latency: 2 * time.Second},
+			{Round: 1, Question: "1 | 1", CorrectAnswer: "Y", Player: "bot-1", Answer: "Y", Correct: true, Latency: time.Second},
+			{Round: 2, Question: "q2", CorrectAnswer: "N", Player: "ann", Answer: "N", Correct: true, Latency: 3 * time.Second},
+			{Round: 2, Question: "q2", CorrectAnswer: "N", Player: "bob"},
+			{Round: 2, Question: "q2", CorrectAnswer: "N", Player: "bot-1", Answer: "Y", Latency: time.Second},
+		},
+	}
+
+	tests := []struct {
+		format string
+		// want is the content of each file, the content must contain every line
+		want map[string][]string
+	}{
+		{
+			format: ReportCSV,
+			want: map[string][]string{
+				"room-1-20240101-090000-ranking.csv": {
+					"rank,player,point,bot,eliminated",
+					"1,ann,20,false,false",
+					"2,bot-1,10,true,false",
+					"2,bob,10,false,false",
+				},
+				"room-1-20240101-090000-questions.csv": {
+					"round,question,answer,players,answered,correct,accuracy",
+					"1,1 | 1,Y,3,3,2,0.67",
+					"2,q2,N,3,2,1,0.33",
+				},
+				"room-1-20240101-090000-answers.csv": {
+					"round,question,player,answer,correct,latency_seconds",
+					"1,1 | 1,ann,Y,true,1.500",
+					"2,q2,bob,,false,",
+				},
+			},
+		},
+		{
+			format: ReportJSON,
+			want: map[string][]string{
+				"room-1-20240101-090000.json": {
+					`"rank": 2,`,
+					`"player": "bot-1",`,
+					`"bot": true`,
+					`"accuracy": 0.6666666666666666`,
+					`"latency_seconds": 1.5`,
+				},
+			},
+		},
+		{
+			format: ReportMarkdown,
+			want: map[string][]string{
+				"room-1-20240101-090000.md": {
+					"# Quiz report: room-1",
+					"| 2 | bot-1 (bot) | 10 |",
+					`| 1 | 1 \| 1 | Y | 2/3 | 67% |`,
+					"| 1 | ann | Y | yes | 1.5s |",
+					"| 2 | bob | - | no | - |",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			files, err := NewReport(result).Files(tt.format)
+			if err != nil {
+				t.Fatalf("Files() error = %v", err)
+			}
+
+			if len(files) != len(tt.want) {
+				t.Fatalf("Files() = %d files, want %d", len(files), len(tt.want))
+			}
+
+			for _, file := range files {
+				lines, ok := tt.want[file.Name]
+				if !ok {
+					t.Errorf("unexpected file %s", file.Name)
+					continue
+				}
+
+				for _, line := range lines {
+					if !strings.Contains(string(file.Data), line) {
+						t.Errorf("%s doesn't contain %q:\n%s", file.Name, line, file.Data)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestParseReportFormats(t *testing.T) {
+	formats, err := ParseReportFormats("md, CSV,markdown")
+	if err != nil || strings.Join(formats, ",") != "md,csv" {
+		t.Errorf("ParseReportFormats() = %v, %v, want md,csv", formats, err)
+	}
+
+	if _, err := ParseReportFormats("pdf"); err == nil {
+		t.Error("ParseReportFormats() error = nil, want error for pdf")
+	}
+}
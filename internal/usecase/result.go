@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"sync"
+	"time"
+)
+
+type (
+	// PlayerScore is the point of a player in a game
+	PlayerScore struct {
+		Name       string
+		Point      int
+		Eliminated bool
+		// Bot is the computer player, it is not rated
+		Bot bool
+	}
+
+	// GameSnapshot is the current state of the game
+	GameSnapshot struct {
+		Mode       string
+		State      State
+		Round      int
+		TotalRound int
+		Scores     []PlayerScore
+		Teams      []TeamScore
+		// Paused is true when the round is paused, Review is true when the game wait for the host after the round
+		Paused bool
+		Review bool
+	}
+
+	// AnswerRecord is the answer of a player to the question of the round
+	AnswerRecord struct {
+		Round    int
+		Question string
+		// CorrectAnswer is the key of the correct option
+		CorrectAnswer string
+		Player        string
+		// Answer is empty when the player doesn't answer, only the first answer is counted
+		Answer  string
+		Correct bool
+		// Latency is the time from the question is sent until the answer, the paused time is not counted
+		Latency time.Duration
+	}
+
+	// GameResult is the final state of a finished game
+	GameResult struct {
+		Room       string
+		FinishedAt time.Time
+		Scores     []PlayerScore
+		Teams      []TeamScore
+		Lifelines  []LifelineUsage
+		Answers    []AnswerRecord
+	}
+
+	// ResultStore is in memory storage for finished games
+	ResultStore struct {
+		mu      sync.RWMutex
+		results []GameResult
+	}
+)
+
+// NewResultStore is ...
+func NewResultStore() *ResultStore {
+	return &ResultStore{}
+}
+
+// Save is ...
+func (s *ResultStore) Save(res GameResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.results = append(s.results, res)
+}
+
+// List return the finished games, filtered by room when room is not empty
+func (s *ResultStore) List(room string) []GameResult {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	results := []GameResult{}
+	for i := 0; i < len(s.results); i++ {
+		if room == "" || s.results[i].Room == room {
+			results = append(results, s.results[i])
+		}
+	}
+
+	return results
+}
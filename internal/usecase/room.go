@@ -2,8 +2,12 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	quiz "github.com/elangreza14/grpc-quiz/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -18,20 +22,117 @@ type (
 		Payload   any
 	}
 
+	// RoomConfig is ...
+	RoomConfig struct {
+		Name       string
+		Mode       string
+		Teams      []string
+		TeamPolicy TeamPolicy
+		// TimeLimit, TimePerQuestion and ClosesAt is only used in async mode
+		TimeLimit       time.Duration
+		TimePerQuestion time.Duration
+		ClosesAt        time.Time
+		// TotalRound limit the question of the game, zero mean all the question
+		TotalRound int
+		// AutoStart start the game when total player is reached, zero mean the host start the game
+		AutoStart int
+		// Temporary room is removed from the lobby when it is done
+		Temporary bool
+		// Rated game update the rating of the player
+		Rated bool
+		// Players is the only players allowed to join, empty mean everyone can join
+		Players []string
+		// Lifelines is the usage count of each lifeline for every player, nil mean the default count
+		Lifelines map[string]int
+		Hints     HintConfig
+		// Adaptive pick the question by the accuracy, nil mean the question is asked in order
+		Adaptive *AdaptiveConfig
+		// Questions is the question of the game, nil mean the question bank of the lobby
+		Questions []QuestionPayload
+		// Draw pick the question from the questions, nil mean every question in order
+		Draw *DrawConfig
+		// Review hold the game after each round until the host start the next round
+		Review bool
+		// Bots join the room when the room is listened, zero count mean no bot
+		Bots BotConfig
+		// Clock and LifelineSeed is passed to the game, it is set by the replay
+		Clock        Clock `json:"-"`
+		LifelineSeed int64
+	}
+
+	// Engine is the game played in the room
+	Engine interface {
+		Start()
+		AddPlayer(name string)
+		RemovePlayer(name string)
+		SubmitAnswer(answer SubmitAnswerPayload)
+		Buzz(payload BuzzPayload) error
+		UseLifeline(payload LifelinePayload) error
+		Lifelines() []LifelineUsage
+		Answers() []AnswerRecord
+		RequestHint(name string) error
+		SetQuestions(questions []QuestionPayload) error
+		Control(action RoundControl, extend time.Duration) error
+		CanAnswer(name string) error
+		Scores() []PlayerScore
+		TeamScores() []TeamScore
+		Snapshot() GameSnapshot
+		GetState()
+		ListenStream() <-chan *GameState
+		// Close stop the goroutine of the game when the room is done
+		Close()
+	}
+
 	// Room is default structure for creating communication
 	Room struct {
+		ID        string
+		Name      string
+		CreatedAt time.Time
+		Teams     *Teams
 		// players  map[string]chan *quiz.StreamResponse
-		players  sync.Map
+		players sync.Map
+		// bots is the name of the bot in the players
+		bots     sync.Map
+		watchers sync.Map
+		watchID  atomic.Int64
 		queue    chan *Event
-		Started  bool
-		Game     *GamePlay
+		// stopped is closed when the queue is not listened anymore, the event is dropped
+		stopped chan bool
+		// started is written by the queue and read by the handlers
+		started  atomic.Bool
+		Game     Engine
 		PowerOff chan bool
+		// saved is closed when the lobby is done with the result of the room
+		saved chan bool
+		// Seed is the seed of the drawn question, zero mean the question is not drawn
+		Seed int64
+
+		autoStart int
+		temporary bool
+		rated     bool
+		allowed   map[string]bool
+		// source is the config before the question is drawn, the room without questions use the bank of the lobby
+		source RoomConfig
+		// log record the event and the state of the room, nil mean the room is not recorded
+		log *EventLog
 	}
 
 	// BroadcastPersonalPayload is ...
 	BroadcastPersonalPayload struct {
 		Name, Message string
 	}
+
+	// TeamChatPayload is ...
+	TeamChatPayload struct {
+		Name, Message string
+	}
+)
+
+var (
+	// ErrPlayerExists is returned when the name of the player is already in the room
+	ErrPlayerExists = errors.New("player already exist")
+	// ErrNotTeamMode is returned when the player choose the team of the room without team
+	ErrNotTeamMode = errors.New("room is not in team mode")
 )
 
 const (
@@ -45,64 +146,287 @@ const (
 	StartGame
 	//  SubmitAnswer is event for submit the answer
 	SubmitAnswer
+	//  TeamChat is event for broadcast to the team of the player
+	TeamChat
+	//  Buzz is event for buzzing in the buzzer mode
+	Buzz
+	//  CloseRoom is event for closing the room which is not started
+	CloseRoom
+	//  UseLifeline is event for using the lifeline in the round
+	UseLifeline
+	//  RequestHint is event for requesting the next hint of the question
+	RequestHint
+	//  ReloadQuestions is event for replacing the questions of the room which is not started
+	ReloadQuestions
 )
 
 // NewRoom is
-func NewRoom() *Room {
-	return &Room{
-		players:  sync.Map{},
-		queue:    make(chan *Event, 100),
-		Game:     NewGamePlay(),
-		PowerOff: make(chan bool),
+func NewRoom(id string, cfg RoomConfig) (*Room, error) {
+	teams, err := NewTeams(cfg.Teams, cfg.TeamPolicy)
+	if err != nil {
+		return nil, err
 	}
+
+	if cfg.Bots.Count > 0 {
+		if err := canAddBots(cfg.Mode); err != nil {
+			return nil, err
+		}
+	}
+
+	// the self-paced quiz has no start step
+	if cfg.Mode == AsyncMode {
+		if teams != nil {
+			return nil, fmt.Errorf("team is not supported in %s mode", AsyncMode)
+		}
+
+		return newRoom(id, cfg, nil, NewAsyncGame(AsyncConfig{
+			TimePerQuestion: cfg.TimePerQuestion,
+			TimeLimit:       cfg.TimeLimit,
+			ClosesAt:        cfg.ClosesAt,
+			Adaptive:        cfg.Adaptive,
+			Questions:       cfg.Questions,
+			Clock:           cfg.Clock,
+		}), true), nil
+	}
+
+	game, err := NewGamePlay(GameConfig{
+		Mode:         cfg.Mode,
+		Teams:        teams,
+		TotalRound:   cfg.TotalRound,
+		Lifelines:    cfg.Lifelines,
+		Hints:        cfg.Hints,
+		Adaptive:     cfg.Adaptive,
+		Questions:    cfg.Questions,
+		Review:       cfg.Review,
+		Clock:        cfg.Clock,
+		LifelineSeed: cfg.LifelineSeed,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newRoom(id, cfg, teams, game, false), nil
+}
+
+func newRoom(id string, cfg RoomConfig, teams *Teams, game Engine, started bool) *Room {
+	var allowed map[string]bool
+	if len(cfg.Players) > 0 {
+		allowed = map[string]bool{}
+		for _, player := range cfg.Players {
+			allowed[player] = true
+		}
+	}
+
+	var seed int64
+	if cfg.Draw != nil {
+		seed = cfg.Draw.Seed
+	}
+
+	room := &Room{
+		ID:        id,
+		Seed:      seed,
+		Name:      cfg.Name,
+		CreatedAt: time.Now(),
+		Teams:     teams,
+		players:   sync.Map{},
+		queue:     make(chan *Event, 100),
+		stopped:   make(chan bool),
+		Game:      game,
+		PowerOff:  make(chan bool),
+		saved:     make(chan bool),
+		autoStart: cfg.AutoStart,
+		temporary: cfg.Temporary,
+		rated:     cfg.Rated,
+		allowed:   allowed,
+	}
+	room.started.Store(started)
+
+	return room
+}
+
+// SetEventLog record the room to the log, it must be set before the queue is listened.
+// The room is written first, so the replay can create the room again
+func (r *Room) SetEventLog(log *EventLog) {
+	r.log = log
+	log.room(r)
+}
+
+// CanJoin return false when the room is only for some players
+func (r *Room) CanJoin(player string) bool {
+	return r.allowed == nil || r.allowed[player]
+}
+
+// Join reserve the player in the room and join the team, the team is empty when the room is not in team mode.
+// The player is reserved before the team is joined, so the player who register twice at the same time join once
+func (r *Room) Join(player, team string) (string, error) {
+	return r.join(player, team, make(chan *quiz.StreamResponse, 100), false)
+}
+
+// join reserve the name of the player, the bot is tagged before it is inserted by the queue
+func (r *Room) join(player, team string, ch chan *quiz.StreamResponse, bot bool) (string, error) {
+	if r.Teams == nil && team != "" {
+		return "", ErrNotTeamMode
+	}
+
+	if _, loaded := r.players.LoadOrStore(player, ch); loaded {
+		return "", ErrPlayerExists
+	}
+
+	if bot {
+		r.bots.Store(player, true)
+	}
+
+	if r.Teams != nil {
+		joined, err := r.Teams.Join(player, team)
+		if err != nil {
+			r.bots.Delete(player)
+			r.players.Delete(player)
+			return "", err
+		}
+		team = joined
+	}
+
+	r.PublishQueue(&Event{
+		EventType: InsertPlayer,
+		Payload:   player,
+	})
+
+	return team, nil
 }
 
 // PublishQueue is ...
 func (r *Room) PublishQueue(evt *Event) {
-	r.queue <- evt
+	select {
+	case r.queue <- evt:
+	case <-r.stopped:
+	}
 }
 
 // ListenQueue is ...
 func (r *Room) ListenQueue(ctx context.Context) {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
+	defer close(r.stopped)
 
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case gameRes := <-r.Game.ListenStream():
+			r.log.state(gameRes)
 			switch gameRes.State {
 			case OnProgress:
-				if gameRes.payload != nil {
-					fmt.Println(gameRes.payload.(string))
-					r.BroadcastToAllPlayer(gameRes.payload.(string))
+				switch payload := gameRes.payload.(type) {
+				case QuestionEvent:
+					fmt.Printf("round %d: %s\n", payload.Round, payload.Question)
+					r.BroadcastEvent(payload.toProto())
 					r.Game.GetState()
+				case AnswerProgress:
+					r.BroadcastEvent(payload.toProto())
+				case BuzzerEvent:
+					r.BroadcastEvent(payload.toProto())
+					if payload.Holder != "" {
+						fmt.Printf("player %s holds the buzzer\n", payload.Holder)
+						r.BroadcastToAllPlayer(fmt.Sprintf("player %s holds the buzzer", payload.Holder))
+					} else {
+						r.BroadcastToAllPlayer("the buzzer is open again, press (B) to buzz")
+					}
+				case BroadcastPersonalPayload:
+					r.BroadcastToSpecificPlayer(payload)
+				case PlayerEvent:
+					r.SendToPlayer(payload.Name, payload.Event.toProto())
+				case RoundResult:
+					r.BroadcastEvent(payload.toProto())
+				case Leaderboard:
+					payload.Scores = r.tagBots(payload.Scores)
+					r.BroadcastEvent(payload.toProto())
+				case RoundPaused:
+					fmt.Printf("round %d is paused, %s left\n", payload.Round, payload.Remaining.Round(time.Second))
+					r.BroadcastEvent(payload.toProto())
+				case RoundResumed:
+					fmt.Printf("round %d ends at %s\n", payload.Round, payload.Deadline.Format(time.TimeOnly))
+					r.BroadcastEvent(payload.toProto())
+				case RoundReview:
+					fmt.Printf("round %d review, type /next to start the next round\n", payload.Round)
+					r.BroadcastEvent(payload.toProto())
+				case string:
+					fmt.Println(payload)
+					r.BroadcastToAllPlayer(payload)
+				default:
 				}
 			case Done:
+				// the result is recorded before the room is done, the server is stopped by the default room
+				r.log.result(r.Scores())
+				r.BroadcastEvent(Leaderboard{Scores: r.Scores(), Teams: r.Game.TeamScores(), Final: true}.toProto())
 				r.BroadcastToAllPlayer("game finished")
 				r.Game.GetState()
-				r.PowerOff <- true
+				close(r.PowerOff)
 			default:
 			}
 		case evt := <-r.queue:
+			r.log.event(r, evt)
 			switch evt.EventType {
 			case InsertPlayer:
-				// initialize the player
+				// the player is reserved by Join, the replayed player is not
 				player := evt.Payload.(string)
-				r.players.Store(player, make(chan *quiz.StreamResponse, 100))
+				r.players.LoadOrStore(player, make(chan *quiz.StreamResponse, 100))
 				r.Game.AddPlayer(player)
 				fmt.Printf("player %s joined. total %d players \n", player, r.TotalPlayer())
+
+				if r.autoStart > 0 && r.TotalPlayer() >= r.autoStart {
+					r.start()
+				}
 			case StartGame:
-				r.BroadcastToAllPlayer("game started")
-				r.Game.Start()
-				r.Started = true
+				r.start()
+			case CloseRoom:
+				if r.Started() {
+					continue
+				}
+				r.BroadcastToAllPlayer("room closed")
+				close(r.PowerOff)
+				return
 			case Broadcast:
 				r.BroadcastToAllPlayer(evt.Payload.(string))
 			case BroadcastPersonal:
 				r.BroadcastToSpecificPlayer(evt.Payload.(BroadcastPersonalPayload))
 			case SubmitAnswer:
 				r.Game.SubmitAnswer(evt.Payload.(SubmitAnswerPayload))
+			case TeamChat:
+				r.BroadcastToTeam(evt.Payload.(TeamChatPayload))
+			case Buzz:
+				payload := evt.Payload.(BuzzPayload)
+				if err := r.Game.Buzz(payload); err != nil {
+					r.BroadcastToSpecificPlayer(BroadcastPersonalPayload{
+						Name:    payload.Name,
+						Message: err.Error(),
+					})
+				}
+			case UseLifeline:
+				payload := evt.Payload.(LifelinePayload)
+				if err := r.Game.UseLifeline(payload); err != nil {
+					r.BroadcastToSpecificPlayer(BroadcastPersonalPayload{
+						Name:    payload.Name,
+						Message: err.Error(),
+					})
+				}
+			case ReloadQuestions:
+				if r.Started() {
+					continue
+				}
+
+				if err := r.Game.SetQuestions(evt.Payload.([]QuestionPayload)); err != nil {
+					fmt.Printf("room %s keep its questions: %v\n", r.ID, err)
+					continue
+				}
+				r.BroadcastToAllPlayer("the question bank is updated")
+			case RequestHint:
+				name := evt.Payload.(string)
+				if err := r.Game.RequestHint(name); err != nil {
+					r.BroadcastToSpecificPlayer(BroadcastPersonalPayload{
+						Name:    name,
+						Message: err.Error(),
+					})
+				}
 			default:
 				// no operation
 			}
@@ -110,8 +434,55 @@ func (r *Room) ListenQueue(ctx context.Context) {
 	}
 }
 
+// Started is true after the game is started, the self-paced quiz is started when the room is created
+func (r *Room) Started() bool {
+	return r.started.Load()
+}
+
+func (r *Room) start() {
+	if !r.started.CompareAndSwap(false, true) {
+		return
+	}
+
+	r.BroadcastToAllPlayer("game started")
+	if r.Seed != 0 {
+		r.BroadcastToAllPlayer(fmt.Sprintf("the questions are drawn with seed %d", r.Seed))
+	}
+	r.Game.Start()
+}
+
+// Control apply the action of the host to the started game
+func (r *Room) Control(action RoundControl, extend time.Duration) error {
+	if !r.Started() {
+		return errors.New("the game is not started")
+	}
+
+	// the action is recorded before it is applied, so the state of the action is written after it
+	r.log.control(action, extend)
+	return r.Game.Control(action, extend)
+}
+
+// Abort close the room when the game is not started
+func (r *Room) Abort() {
+	r.PublishQueue(&Event{
+		EventType: CloseRoom,
+	})
+}
+
 // BroadcastToAllPlayer is ...
 func (r *Room) BroadcastToAllPlayer(msg string, playerException ...string) {
+	r.BroadcastEvent(&quiz.StreamResponse{
+		Timestamp: timestamppb.Now(),
+		Event: &quiz.StreamResponse_ServerAnnouncement{
+			ServerAnnouncement: &quiz.Message{
+				Message: msg,
+			},
+		},
+	}, playerException...)
+}
+
+// BroadcastEvent send the event to all the player and the watcher
+func (r *Room) BroadcastEvent(res *quiz.StreamResponse, playerException ...string) {
 	r.players.Range(func(key, value any) bool {
 		for i := 0; i < len(playerException); i++ {
 			if playerException[i] == key.(string) {
@@ -121,52 +492,96 @@ func (r *Room) BroadcastToAllPlayer(msg string, playerException ...string) {
 
 		ch, okChan := value.(chan *quiz.StreamResponse)
 		if okChan {
-			ch <- &quiz.StreamResponse{
-				Timestamp: timestamppb.Now(),
-				Event: &quiz.StreamResponse_ServerAnnouncement{
-					ServerAnnouncement: &quiz.Message{
-						Message: msg,
-					},
-				},
-			}
+			r.send(key.(string), ch, res)
 		}
 
 		return true
 	})
+
+	r.broadcastToWatcher(res)
+}
+
+// send never block the room. The player who doesn't read the stream, e.g. registered without opening the stream,
+// is removed from the room when the channel is full
+func (r *Room) send(player string, ch chan *quiz.StreamResponse, res *quiz.StreamResponse) {
+	select {
+	case ch <- res:
+	default:
+		fmt.Printf("player %s doesn't read the stream, removed from the room\n", player)
+		r.RemovePlayer(player)
+	}
+}
+
+// Watch subscribe to every event broadcasted to all the player.
+// The watcher is not a player, it can't answer the question.
+// The returned function must be called to stop watching
+func (r *Room) Watch() (<-chan *quiz.StreamResponse, func()) {
+	id := r.watchID.Add(1)
+	ch := make(chan *quiz.StreamResponse, 100)
+	r.watchers.Store(id, ch)
+
+	return ch, func() { r.watchers.Delete(id) }
+}
+
+// broadcastToWatcher never block the room, slow watcher will miss the event
+func (r *Room) broadcastToWatcher(res *quiz.StreamResponse) {
+	r.watchers.Range(func(_, value any) bool {
+		select {
+		case value.(chan *quiz.StreamResponse) <- res:
+		default:
+		}
+
+		return true
+	})
+}
+
+// SendToPlayer send the event to the player only
+func (r *Room) SendToPlayer(player string, res *quiz.StreamResponse) {
+	if ch, ok := r.GetPlayerDetail(player); ok {
+		r.send(player, ch, res)
+	}
 }
 
 // BroadcastToSpecificPlayer is ...
 func (r *Room) BroadcastToSpecificPlayer(req BroadcastPersonalPayload) {
-	msgPlayer, ok := r.players.Load(req.Name)
-	if ok {
-		ch, okChan := msgPlayer.(chan *quiz.StreamResponse)
-		if okChan {
-			ch <- &quiz.StreamResponse{
-				Timestamp: timestamppb.Now(),
-				Event: &quiz.StreamResponse_ServerAnnouncement{
-					ServerAnnouncement: &quiz.Message{
-						Message: req.Message,
-					},
+	if ch, ok := r.GetPlayerDetail(req.Name); ok {
+		r.send(req.Name, ch, &quiz.StreamResponse{
+			Timestamp: timestamppb.Now(),
+			Event: &quiz.StreamResponse_ServerAnnouncement{
+				ServerAnnouncement: &quiz.Message{
+					Message: req.Message,
 				},
-			}
-		}
+			},
+		})
+	}
+}
+
+// BroadcastToTeam is private chat for the member of the team
+func (r *Room) BroadcastToTeam(req TeamChatPayload) {
+	if r.Teams == nil {
+		return
+	}
+
+	team, ok := r.Teams.TeamOf(req.Name)
+	if !ok {
+		return
+	}
+
+	for _, member := range r.Teams.Members(team) {
+		r.BroadcastToSpecificPlayer(BroadcastPersonalPayload{
+			Name:    member,
+			Message: fmt.Sprintf("[team %s] %s: %s", team, req.Name, req.Message),
+		})
 	}
 }
 
 // ShutdownClient is ...
 func (r *Room) ShutdownClient() {
-	r.players.Range(func(key, value any) bool {
-		ch, okChan := value.(chan *quiz.StreamResponse)
-		if okChan {
-			ch <- &quiz.StreamResponse{
-				Timestamp: timestamppb.Now(),
-				Event: &quiz.StreamResponse_ServerShutdown{
-					ServerShutdown: &quiz.Shutdown{},
-				},
-			}
-		}
-
-		return true
+	r.BroadcastEvent(&quiz.StreamResponse{
+		Timestamp: timestamppb.Now(),
+		Event: &quiz.StreamResponse_ServerShutdown{
+			ServerShutdown: &quiz.Shutdown{},
+		},
 	})
 }
 
@@ -185,8 +600,32 @@ func (r *Room) GetPlayerDetail(player string) (chan *quiz.StreamResponse, bool)
 
 // RemovePlayer is ...
 func (r *Room) RemovePlayer(player string) {
+	r.log.remove(player)
 	r.players.Delete(player)
 	r.Game.RemovePlayer(player)
+	if r.Teams != nil {
+		r.Teams.Leave(player)
+	}
+}
+
+// Leave remove the player when the stream is closed. The player who is removed by the room
+// and registered again keep the new stream
+func (r *Room) Leave(player string, ch chan *quiz.StreamResponse) {
+	if r.players.CompareAndDelete(player, ch) {
+		r.RemovePlayer(player)
+	}
+}
+
+// Players is the name of the player in the room
+func (r *Room) Players() []string {
+	players := []string{}
+	r.players.Range(func(key, _ any) bool {
+		players = append(players, key.(string))
+		return true
+	})
+	sort.Strings(players)
+
+	return players
 }
 
 // TotalPlayer is ...
@@ -200,7 +639,62 @@ func (r *Room) TotalPlayer() int {
 	return total
 }
 
+// TotalSpectator is ...
+func (r *Room) TotalSpectator() int {
+	total := 0
+	r.watchers.Range(func(_, _ any) bool {
+		total++
+		return true
+	})
+
+	return total
+}
+
 // Done is ...
 func (r *Room) Done() <-chan bool {
 	return r.PowerOff
 }
+
+// Saved is closed after the lobby saved the result and the report of the room, or the lobby is stopped
+func (r *Room) Saved() <-chan bool {
+	return r.saved
+}
+
+// Result is ...
+func (r *Room) Result() GameResult {
+	return GameResult{
+		Room:       r.ID,
+		FinishedAt: time.Now(),
+		Scores:     r.Scores(),
+		Teams:      r.Game.TeamScores(),
+		Lifelines:  r.Game.Lifelines(),
+		Answers:    r.Game.Answers(),
+	}
+}
+
+// IsBot is true when the player is the bot
+func (r *Room) IsBot(player string) bool {
+	_, ok := r.bots.Load(player)
+	return ok
+}
+
+// Scores is the score of the game, the bot is tagged
+func (r *Room) Scores() []PlayerScore {
+	return r.tagBots(r.Game.Scores())
+}
+
+// Snapshot is the state of the game, the bot is tagged
+func (r *Room) Snapshot() GameSnapshot {
+	snapshot := r.Game.Snapshot()
+	snapshot.Scores = r.tagBots(snapshot.Scores)
+
+	return snapshot
+}
+
+func (r *Room) tagBots(scores []PlayerScore) []PlayerScore {
+	for i := range scores {
+		scores[i].Bot = r.IsBot(scores[i].Name)
+	}
+
+	return scores
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	quiz "github.com/elangreza14/grpc-quiz/proto"
+)
+
+func TestRoomBroadcastSlowPlayer(t *testing.T) {
+	room, err := NewRoom("room-1", RoomConfig{})
+	if err != nil {
+		t.Fatalf("NewRoom() error = %v", err)
+	}
+
+	// ann read the stream, bob is registered without the stream
+	ann := make(chan *quiz.StreamResponse, 100)
+	bob := make(chan *quiz.StreamResponse, 100)
+	room.players.Store("ann", ann)
+	room.players.Store("bob", bob)
+
+	for i := 0; i <= cap(bob); i++ {
+		room.BroadcastToAllPlayer(fmt.Sprintf("message %d", i))
+		<-ann
+	}
+
+	if got := room.Players(); len(got) != 1 || got[0] != "ann" {
+		t.Errorf("Players() = %v, want [ann]", got)
+	}
+
+	// the stream of bob is closed after bob is registered again
+	room.players.Store("bob", make(chan *quiz.StreamResponse, 100))
+	room.Leave("bob", bob)
+	if _, ok := room.GetPlayerDetail("bob"); !ok {
+		t.Error("the new stream of bob is removed by the old stream")
+	}
+}
+
+func TestRoomJoin(t *testing.T) {
+	if _, err := NewRoom("room-1", RoomConfig{Teams: []string{"", ""}}); err == nil {
+		t.Error("NewRoom() without the name of the team, want error")
+	}
+
+	room, err := NewRoom("room-1", RoomConfig{Teams: []string{"red", "blue"}})
+	if err != nil {
+		t.Fatalf("NewRoom() error = %v", err)
+	}
+
+	// the same player register twice at the same time
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		joined int
+	)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := room.Join("ann", "")
+			if errors.Is(err, ErrPlayerExists) {
+				return
+			}
+			if err != nil {
+				t.Errorf("Join() error = %v", err)
+				return
+			}
+
+			mu.Lock()
+			joined++
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if joined != 1 {
+		t.Errorf("ann joined %d times, want once", joined)
+	}
+	if members := len(room.Teams.Members("red")) + len(room.Teams.Members("blue")); members != 1 {
+		t.Errorf("total member = %d, want 1", members)
+	}
+
+	// the player who choose the unknown team is not reserved
+	if _, err := room.Join("bob", "green"); err == nil {
+		t.Error("Join() to the unknown team, want error")
+	}
+	if _, ok := room.GetPlayerDetail("bob"); ok {
+		t.Error("bob is in the room after the join is failed")
+	}
+}
+
+func TestLobbyStopRoom(t *testing.T) {
+	lobby, err := NewLobby(RoomConfig{})
+	if err != nil {
+		t.Fatalf("NewLobby() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go lobby.ListenRooms(ctx)
+
+	room, err := lobby.CreateRoom(RoomConfig{
+		Questions: []QuestionPayload{{question: "q1", answer: "Y"}},
+		AutoStart: 1,
+		Temporary: true,
+	})
+	if err != nil {
+		t.Fatalf("CreateRoom() error = %v", err)
+	}
+
+	events, stop := room.Watch()
+	defer stop()
+	if _, err := room.Join("ann", ""); err != nil {
+		t.Fatalf("Join() error = %v", err)
+	}
+	for res := range events {
+		if res.GetQuestion() != nil {
+			break
+		}
+	}
+	room.PublishQueue(&Event{EventType: SubmitAnswer, Payload: SubmitAnswerPayload{Name: "ann", Answer: "Y"}})
+
+	select {
+	case <-room.Saved():
+	case <-time.After(idleTimeout):
+		t.Fatalf("the result is not saved after %s", idleTimeout)
+	}
+
+	if _, ok := lobby.GetRoom(room.ID); ok {
+		t.Error("the temporary room is still in the lobby")
+	}
+
+	// the queue and the game of the done room is stopped, the late event is dropped
+	select {
+	case <-room.stopped:
+	case <-time.After(idleTimeout):
+		t.Fatal("the queue of the room is still listened")
+	}
+	select {
+	case <-room.Game.(*GamePlay).stopStream:
+	default:
+		t.Error("the game of the room is not closed")
+	}
+	for i := 0; i <= cap(room.queue); i++ {
+		room.PublishQueue(&Event{EventType: Broadcast, Payload: "late"})
+	}
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	quiz "github.com/elangreza14/grpc-quiz/proto"
+)
+
+// DelayEvent hold every event for the delay before it is released,
+// so the spectator can't leak the answer to the player in real time
+func DelayEvent(ctx context.Context, events <-chan *quiz.StreamResponse, delay time.Duration) <-chan *quiz.StreamResponse {
+	if delay <= 0 {
+		return events
+	}
+
+	type delayedEvent struct {
+		due time.Time
+		res *quiz.StreamResponse
+	}
+
+	buffer := make(chan delayedEvent, 1000)
+	out := make(chan *quiz.StreamResponse)
+
+	go func() {
+		defer close(buffer)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case res := <-events:
+				select {
+				case buffer <- delayedEvent{due: time.Now().Add(delay), res: res}:
+				default:
+					// buffer is full, drop the event like the slow watcher
+				}
+			}
+		}
+	}()
+
+	go func() {
+		defer close(out)
+		for evt := range buffer {
+			timer := time.NewTimer(time.Until(evt.due))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case out <- evt.res:
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+type (
+	// TeamPolicy is how the answer of the team is scored
+	TeamPolicy int
+
+	// TeamScore is the point of a team in a game
+	TeamScore struct {
+		Name    string
+		Point   float64
+		Members []string
+	}
+
+	// Teams is the roster of the team in a room
+	Teams struct {
+		mu      sync.RWMutex
+		Policy  TeamPolicy
+		names   []string
+		members map[string][]string
+		teamOf  map[string]string
+		points  map[string]int
+	}
+)
+
+const (
+	// AverageScore is policy where each member answer individually and the point is averaged
+	AverageScore TeamPolicy = iota
+	// CaptainAnswer is policy where only the answer of the captain is counted for the team
+	CaptainAnswer
+)
+
+// NewTeams return nil when there is no team, the room is not in team mode.
+// It return error when every name of the team is empty, e.g. -t ","
+func NewTeams(names []string, policy TeamPolicy) (*Teams, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	t := &Teams{
+		Policy:  policy,
+		members: map[string][]string{},
+		teamOf:  map[string]string{},
+		points:  map[string]int{},
+	}
+
+	for _, name := range names {
+		if _, ok := t.members[name]; ok || name == "" {
+			continue
+		}
+		t.names = append(t.names, name)
+		t.members[name] = []string{}
+	}
+
+	if len(t.names) == 0 {
+		return nil, errors.New("the name of the team is required")
+	}
+
+	return t, nil
+}
+
+// Join the player to the team, empty team will assign the player to the smallest team
+func (t *Teams) Join(player, team string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.names) == 0 {
+		return "", errors.New("the room has no team")
+	}
+
+	if team == "" {
+		team = t.names[0]
+		for _, name := range t.names {
+			if len(t.members[name]) < len(t.members[team]) {
+				team = name
+			}
+		}
+	}
+
+	if _, ok := t.members[team]; !ok {
+		return "", fmt.Errorf("team %s not found", team)
+	}
+
+	t.members[team] = append(t.members[team], player)
+	t.teamOf[player] = team
+
+	return team, nil
+}
+
+// Leave remove the player from the team, the next member will be the captain
+func (t *Teams) Leave(player string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	team, ok := t.teamOf[player]
+	if !ok {
+		return
+	}
+
+	members := []string{}
+	for _, member := range t.members[team] {
+		if member != player {
+			members = append(members, member)
+		}
+	}
+
+	t.members[team] = members
+	delete(t.teamOf, player)
+}
+
+// Names is ...
+func (t *Teams) Names() []string {
+	return append([]string{}, t.names...)
+}
+
+// TeamOf is ...
+func (t *Teams) TeamOf(player string) (string, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	team, ok := t.teamOf[player]
+	return team, ok
+}
+
+// Members is ...
+func (t *Teams) Members(team string) []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return append([]string{}, t.members[team]...)
+}
+
+// IsCaptain the first member who joined the team is the captain
+func (t *Teams) IsCaptain(player string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	team, ok := t.teamOf[player]
+	return ok && t.members[team][0] == player
+}
+
+// CanAnswer is ...
+func (t *Teams) CanAnswer(player string) bool {
+	return t.Policy != CaptainAnswer || t.IsCaptain(player)
+}
+
+// AddPoint is used by captain policy, when the captain answer correctly
+func (t *Teams) AddPoint(player string, point int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if team, ok := t.teamOf[player]; ok {
+		t.points[team] += point
+	}
+}
+
+// Scores return the point of each team, sorted from the highest point
+func (t *Teams) Scores(players []PlayerScore) []TeamScore {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	points := map[string]int{}
+	for _, player := range players {
+		points[player.Name] = player.Point
+	}
+
+	teams := []TeamScore{}
+	for _, name := range t.names {
+		team := TeamScore{
+			Name:    name,
+			Members: append([]string{}, t.members[name]...),
+		}
+
+		switch t.Policy {
+		case CaptainAnswer:
+			team.Point = float64(t.points[name])
+		default:
+			total := 0
+			for _, member := range team.Members {
+				total += points[member]
+			}
+			if len(team.Members) > 0 {
+				team.Point = float64(total) / float64(len(team.Members))
+			}
+		}
+
+		teams = append(teams, team)
+	}
+
+	sort.SliceStable(teams, func(i, j int) bool {
+		return teams[i].Point > teams[j].Point
+	})
+
+	return teams
+}
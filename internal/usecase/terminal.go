@@ -3,7 +3,7 @@ package usecase
 import (
 	"bufio"
 	"errors"
-	"os"
+	"io"
 	"strings"
 )
 
@@ -12,9 +12,9 @@ type Terminal struct {
 	scanner *bufio.Scanner
 }
 
-// NewTerminal ...
-func NewTerminal() *Terminal {
-	scanner := bufio.NewScanner(os.Stdin)
+// NewTerminal read the line from r, it is os.Stdin for the cli and the script for the test
+func NewTerminal(r io.Reader) *Terminal {
+	scanner := bufio.NewScanner(r)
 	scanner.Split(bufio.ScanLines)
 
 	return &Terminal{
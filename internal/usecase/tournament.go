@@ -0,0 +1,586 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// SingleElimination is knockout tournament, the loser of the match is out
+	SingleElimination = "single-elimination"
+	// RoundRobin is tournament where every player play every other player
+	RoundRobin = "round-robin"
+
+	// TournamentJoinTimeout is the time for the players to join the match room
+	TournamentJoinTimeout = 2 * time.Minute
+)
+
+var (
+	// ErrTournamentNotFound is ...
+	ErrTournamentNotFound = errors.New("tournament not found")
+	// ErrTournamentStarted is ...
+	ErrTournamentStarted = errors.New("tournament already started")
+)
+
+type (
+	// TournamentConfig is ...
+	TournamentConfig struct {
+		Name    string
+		Format  string
+		Players []string
+	}
+
+	// Tournament is the bracket of many duel games
+	Tournament struct {
+		ID     string
+		Name   string
+		Format string
+		State  State
+		// Players is ordered by the seed, the first player is the top seed
+		Players   []string
+		Rounds    [][]*TournamentMatch
+		Winner    string
+		CreatedAt time.Time
+	}
+
+	// TournamentMatch is a duel in the tournament, the match with one player is a bye
+	TournamentMatch struct {
+		ID      string
+		Round   int
+		Players []string
+		Room    string
+		State   State
+		Winner  string
+		Scores  []PlayerScore
+		Bye     bool
+	}
+
+	// Standing is the record of the player in the tournament
+	Standing struct {
+		Player string
+		Win    int
+		Draw   int
+		Loss   int
+		Point  int
+	}
+
+	// Tournaments seed the bracket, create the room for each match and advance the winner.
+	// The state is saved as json file in the dir, empty dir mean in memory only
+	Tournaments struct {
+		mu          sync.Mutex
+		lobby       *Lobby
+		dir         string
+		total       int
+		tournaments map[string]*Tournament
+	}
+)
+
+// NewTournaments load the saved tournaments and resume the unfinished one
+func NewTournaments(lobby *Lobby, dir string) (*Tournaments, error) {
+	t := &Tournaments{
+		lobby:       lobby,
+		dir:         dir,
+		tournaments: map[string]*Tournament{},
+	}
+
+	if dir == "" {
+		return t, nil
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		tournament := &Tournament{}
+		if err = json.Unmarshal(data, tournament); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+
+		t.tournaments[tournament.ID] = tournament
+		t.total++
+	}
+
+	// the room of the unfinished match is gone with the previous server
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, tournament := range t.tournaments {
+		if tournament.State == OnProgress {
+			if err := t.startRound(tournament, t.currentRound(tournament)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return t, nil
+}
+
+// Create seed the players by the rating, the player with the same rating keep the given order
+func (t *Tournaments) Create(cfg TournamentConfig) (*Tournament, error) {
+	if cfg.Format == "" {
+		cfg.Format = SingleElimination
+	}
+
+	if cfg.Format != SingleElimination && cfg.Format != RoundRobin {
+		return nil, fmt.Errorf("tournament format %s not found", cfg.Format)
+	}
+
+	players := []string{}
+	seen := map[string]bool{}
+	for _, player := range cfg.Players {
+		player = strings.TrimSpace(player)
+		if player == "" || seen[player] {
+			continue
+		}
+		seen[player] = true
+		players = append(players, player)
+	}
+
+	if len(players) < 2 {
+		return nil, errors.New("tournament need at least 2 players")
+	}
+
+	sort.SliceStable(players, func(i, j int) bool {
+		return t.lobby.Ratings.Get(players[i]) > t.lobby.Ratings.Get(players[j])
+	})
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.total++
+	tournament := &Tournament{
+		ID:        fmt.Sprintf("tournament-%d", t.total),
+		Name:      cfg.Name,
+		Format:    cfg.Format,
+		State:     Waiting,
+		Players:   players,
+		CreatedAt: time.Now(),
+	}
+	if tournament.Name == "" {
+		tournament.Name = tournament.ID
+	}
+
+	if tournament.Format == SingleElimination {
+		tournament.Rounds = [][]*TournamentMatch{seedBracket(players)}
+	} else {
+		tournament.Rounds = roundRobin(players)
+	}
+
+	t.tournaments[tournament.ID] = tournament
+
+	return tournament.clone(), t.save(tournament)
+}
+
+// Start create the room of the first round
+func (t *Tournaments) Start(id string) (*Tournament, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tournament, ok := t.tournaments[id]
+	if !ok {
+		return nil, ErrTournamentNotFound
+	}
+
+	if tournament.State != Waiting {
+		return nil, ErrTournamentStarted
+	}
+
+	tournament.State = OnProgress
+	if err := t.startRound(tournament, 0); err != nil {
+		return nil, err
+	}
+
+	return tournament.clone(), nil
+}
+
+// Get is ...
+func (t *Tournaments) Get(id string) (*Tournament, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tournament, ok := t.tournaments[id]
+	if !ok {
+		return nil, ErrTournamentNotFound
+	}
+
+	return tournament.clone(), nil
+}
+
+// List is sorted by the created time
+func (t *Tournaments) List() []*Tournament {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	res := []*Tournament{}
+	for _, tournament := range t.tournaments {
+		res = append(res, tournament.clone())
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].CreatedAt.Before(res[j].CreatedAt)
+	})
+
+	return res
+}
+
+// startRound must be called when t.mu is locked
+func (t *Tournaments) startRound(tournament *Tournament, round int) error {
+	for _, match := range tournament.Rounds[round] {
+		if match.State == Done {
+			continue
+		}
+
+		room, err := t.lobby.CreateRoom(RoomConfig{
+			Name:       fmt.Sprintf("%s %s", tournament.Name, match.ID),
+			Mode:       ClassicMode,
+			TotalRound: DuelRounds,
+			AutoStart:  len(match.Players),
+			Temporary:  true,
+			Rated:      true,
+			Players:    match.Players,
+		})
+		if err != nil {
+			return err
+		}
+
+		match.Room = room.ID
+		match.State = OnProgress
+		time.AfterFunc(TournamentJoinTimeout, room.Abort)
+
+		go t.watchMatch(tournament.ID, match.ID, room)
+	}
+
+	if t.roundDone(tournament, round) {
+		return t.advance(tournament, round)
+	}
+
+	return t.save(tournament)
+}
+
+// watchMatch record the result of the match when the room is done
+func (t *Tournaments) watchMatch(id, matchID string, room *Room) {
+	<-room.Done()
+
+	scores := []PlayerScore{}
+	if room.Started() {
+		scores = room.Result().Scores
+	} else {
+		// the player who doesn't join the room lose the match
+		for _, player := range room.Players() {
+			scores = append(scores, PlayerScore{Name: player, Point: 1})
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tournament := t.tournaments[id]
+	for round, matches := range tournament.Rounds {
+		for _, match := range matches {
+			if match.ID != matchID || match.State == Done {
+				continue
+			}
+
+			match.finish(tournament, scores)
+			fmt.Printf("%s %s winner: %s\n", tournament.Name, match.ID, match.Winner)
+
+			if t.roundDone(tournament, round) {
+				if err := t.advance(tournament, round); err != nil {
+					fmt.Println(err.Error())
+				}
+				return
+			}
+
+			if err := t.save(tournament); err != nil {
+				fmt.Println(err.Error())
+			}
+			return
+		}
+	}
+}
+
+// roundDone must be called when t.mu is locked
+func (t *Tournaments) roundDone(tournament *Tournament, round int) bool {
+	for _, match := range tournament.Rounds[round] {
+		if match.State != Done {
+			return false
+		}
+	}
+
+	return true
+}
+
+// currentRound must be called when t.mu is locked
+func (t *Tournaments) currentRound(tournament *Tournament) int {
+	for round := range tournament.Rounds {
+		if !t.roundDone(tournament, round) {
+			return round
+		}
+	}
+
+	return len(tournament.Rounds) - 1
+}
+
+// advance start the next round or finish the tournament, it must be called when t.mu is locked
+func (t *Tournaments) advance(tournament *Tournament, round int) error {
+	if tournament.Format == RoundRobin {
+		if round+1 < len(tournament.Rounds) {
+			return t.startRound(tournament, round+1)
+		}
+
+		tournament.State = Done
+		tournament.Winner = tournament.Standings()[0].Player
+		fmt.Printf("%s winner: %s\n", tournament.Name, tournament.Winner)
+
+		return t.save(tournament)
+	}
+
+	winners := []string{}
+	for _, match := range tournament.Rounds[round] {
+		winners = append(winners, match.Winner)
+	}
+
+	if len(winners) == 1 {
+		tournament.State = Done
+		tournament.Winner = winners[0]
+		fmt.Printf("%s winner: %s\n", tournament.Name, tournament.Winner)
+
+		return t.save(tournament)
+	}
+
+	next := []*TournamentMatch{}
+	for i := 0; i < len(winners); i += 2 {
+		next = append(next, newMatch(round+1, len(next)+1, winners[i], winners[i+1]))
+	}
+	tournament.Rounds = append(tournament.Rounds, next)
+
+	return t.startRound(tournament, round+1)
+}
+
+// save must be called when t.mu is locked
+func (t *Tournaments) save(tournament *Tournament) error {
+	if t.dir == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(tournament, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// write then rename, the saved state is never half written
+	file := filepath.Join(t.dir, tournament.ID+".json")
+	if err = os.WriteFile(file+".tmp", data, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(file+".tmp", file)
+}
+
+// finish record the result, the tie in the knockout is won by the higher seed
+func (m *TournamentMatch) finish(tournament *Tournament, scores []PlayerScore) {
+	m.State = Done
+	m.Scores = []PlayerScore{}
+
+	points := map[string]int{}
+	for _, score := range scores {
+		points[score.Name] = score.Point
+	}
+
+	for _, player := range m.Players {
+		m.Scores = append(m.Scores, PlayerScore{Name: player, Point: points[player]})
+	}
+
+	best := m.Scores[0]
+	draw := false
+	for _, score := range m.Scores[1:] {
+		if score.Point > best.Point {
+			best, draw = score, false
+		} else if score.Point == best.Point {
+			draw = true
+		}
+	}
+
+	switch {
+	case !draw:
+		m.Winner = best.Name
+	case tournament.Format == SingleElimination:
+		m.Winner = tournament.higherSeed(m.Players)
+	default:
+		m.Winner = ""
+	}
+}
+
+func (t *Tournament) higherSeed(players []string) string {
+	for _, seed := range t.Players {
+		for _, player := range players {
+			if seed == player {
+				return player
+			}
+		}
+	}
+
+	return players[0]
+}
+
+// Standings is sorted by win, draw then point
+func (t *Tournament) Standings() []Standing {
+	standings := map[string]*Standing{}
+	for _, player := range t.Players {
+		standings[player] = &Standing{Player: player}
+	}
+
+	for _, matches := range t.Rounds {
+		for _, match := range matches {
+			if match.State != Done || match.Bye {
+				continue
+			}
+
+			for _, score := range match.Scores {
+				standing := standings[score.Name]
+				standing.Point += score.Point
+				switch match.Winner {
+				case score.Name:
+					standing.Win++
+				case "":
+					standing.Draw++
+				default:
+					standing.Loss++
+				}
+			}
+		}
+	}
+
+	res := []Standing{}
+	for _, player := range t.Players {
+		res = append(res, *standings[player])
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Win != res[j].Win {
+			return res[i].Win > res[j].Win
+		}
+
+		if res[i].Draw != res[j].Draw {
+			return res[i].Draw > res[j].Draw
+		}
+
+		return res[i].Point > res[j].Point
+	})
+
+	return res
+}
+
+func (t *Tournament) clone() *Tournament {
+	res := *t
+	res.Players = append([]string{}, t.Players...)
+	res.Rounds = [][]*TournamentMatch{}
+	for _, matches := range t.Rounds {
+		round := []*TournamentMatch{}
+		for _, match := range matches {
+			m := *match
+			m.Players = append([]string{}, match.Players...)
+			m.Scores = append([]PlayerScore{}, match.Scores...)
+			round = append(round, &m)
+		}
+		res.Rounds = append(res.Rounds, round)
+	}
+
+	return &res
+}
+
+func newMatch(round, number int, players ...string) *TournamentMatch {
+	match := &TournamentMatch{
+		ID:      fmt.Sprintf("r%d-m%d", round+1, number),
+		Round:   round + 1,
+		Players: players,
+		State:   Waiting,
+	}
+
+	// the bye is won without playing
+	if len(players) == 1 {
+		match.Bye = true
+		match.State = Done
+		match.Winner = players[0]
+	}
+
+	return match
+}
+
+// seedBracket pair the top seed with the bottom seed, the missing player is a bye
+func seedBracket(players []string) []*TournamentMatch {
+	size := 1
+	for size < len(players) {
+		size *= 2
+	}
+
+	// the standard bracket order keep the top seeds apart until the final
+	order := []int{0}
+	for len(order) < size {
+		next := []int{}
+		for _, seed := range order {
+			next = append(next, seed, 2*len(order)-1-seed)
+		}
+		order = next
+	}
+
+	matches := []*TournamentMatch{}
+	for i := 0; i < size; i += 2 {
+		pair := []string{}
+		for _, seed := range order[i : i+2] {
+			if seed < len(players) {
+				pair = append(pair, players[seed])
+			}
+		}
+		matches = append(matches, newMatch(0, len(matches)+1, pair...))
+	}
+
+	return matches
+}
+
+// roundRobin schedule every pair of player with the circle method, the odd player out get a bye
+func roundRobin(players []string) [][]*TournamentMatch {
+	circle := append([]string{}, players...)
+	if len(circle)%2 == 1 {
+		circle = append(circle, "")
+	}
+
+	rounds := [][]*TournamentMatch{}
+	for round := 0; round < len(circle)-1; round++ {
+		matches := []*TournamentMatch{}
+		for i := 0; i < len(circle)/2; i++ {
+			a, b := circle[i], circle[len(circle)-1-i]
+			switch {
+			case a == "":
+				matches = append(matches, newMatch(round, len(matches)+1, b))
+			case b == "":
+				matches = append(matches, newMatch(round, len(matches)+1, a))
+			default:
+				matches = append(matches, newMatch(round, len(matches)+1, a, b))
+			}
+		}
+		rounds = append(rounds, matches)
+
+		// the first player is fixed, the others rotate
+		circle = append([]string{circle[0], circle[len(circle)-1]}, circle[1:len(circle)-1]...)
+	}
+
+	return rounds
+}
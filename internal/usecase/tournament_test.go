@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// pairs is the players of each match, the bye is the match of one player
+func pairs(matches []*TournamentMatch) []string {
+	res := []string{}
+	for _, match := range matches {
+		res = append(res, strings.Join(match.Players, "-"))
+	}
+
+	return res
+}
+
+func TestSeedBracket(t *testing.T) {
+	tests := []struct {
+		name    string
+		players []string
+		want    []string
+		byes    int
+	}{
+		{
+			name:    "3 players, the top seed get the bye",
+			players: []string{"s1", "s2", "s3"},
+			want:    []string{"s1", "s2-s3"},
+			byes:    1,
+		},
+		{
+			name:    "4 players, the top seed meet the bottom seed",
+			players: []string{"s1", "s2", "s3", "s4"},
+			want:    []string{"s1-s4", "s2-s3"},
+		},
+		{
+			name:    "5 players, the top 3 seeds get the bye and are kept apart",
+			players: []string{"s1", "s2", "s3", "s4", "s5"},
+			want:    []string{"s1", "s4-s5", "s2", "s3"},
+			byes:    3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := seedBracket(tt.players)
+			if got := pairs(matches); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("seedBracket() = %v, want %v", got, tt.want)
+			}
+
+			byes := 0
+			for _, match := range matches {
+				if match.Bye {
+					byes++
+					if match.State != Done || match.Winner != match.Players[0] {
+						t.Errorf("bye %s = %+v, want won by %s", match.ID, match, match.Players[0])
+					}
+				}
+			}
+			if byes != tt.byes {
+				t.Errorf("total bye = %d, want %d", byes, tt.byes)
+			}
+		})
+	}
+}
+
+func TestRoundRobin(t *testing.T) {
+	tests := []struct {
+		name       string
+		players    []string
+		wantRounds int
+		want       [][]string
+	}{
+		{
+			name:       "3 players, each player get one bye",
+			players:    []string{"a", "b", "c"},
+			wantRounds: 3,
+			want:       [][]string{{"a", "b-c"}, {"a-c", "b"}, {"a-b", "c"}},
+		},
+		{
+			name:       "4 players",
+			players:    []string{"a", "b", "c", "d"},
+			wantRounds: 3,
+			want:       [][]string{{"a-d", "b-c"}, {"a-c", "d-b"}, {"a-b", "c-d"}},
+		},
+		{
+			name:       "5 players, each player get one bye",
+			players:    []string{"a", "b", "c", "d", "e"},
+			wantRounds: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rounds := roundRobin(tt.players)
+			if len(rounds) != tt.wantRounds {
+				t.Fatalf("total round = %d, want %d", len(rounds), tt.wantRounds)
+			}
+
+			if tt.want != nil {
+				got := [][]string{}
+				for _, matches := range rounds {
+					got = append(got, pairs(matches))
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("roundRobin() = %v, want %v", got, tt.want)
+				}
+			}
+
+			// every pair meet once, and the player play once in each round
+			met := map[string]int{}
+			byes := map[string]int{}
+			for i, matches := range rounds {
+				played := map[string]bool{}
+				for _, match := range matches {
+					for _, player := range match.Players {
+						if played[player] {
+							t.Errorf("round %d: %s play twice", i+1, player)
+						}
+						played[player] = true
+					}
+
+					if match.Bye {
+						byes[match.Players[0]]++
+						continue
+					}
+
+					pair := append([]string{}, match.Players...)
+					sort.Strings(pair)
+					met[strings.Join(pair, "-")]++
+				}
+
+				if len(played) != len(tt.players) {
+					t.Errorf("round %d: %d players, want %d", i+1, len(played), len(tt.players))
+				}
+			}
+
+			if want := len(tt.players) * (len(tt.players) - 1) / 2; len(met) != want {
+				t.Errorf("total pair = %d, want %d", len(met), want)
+			}
+			for pair, total := range met {
+				if total != 1 {
+					t.Errorf("%s meet %d times, want once", pair, total)
+				}
+			}
+
+			for _, player := range tt.players {
+				if want := len(tt.players) % 2; byes[player] != want {
+					t.Errorf("bye of %s = %d, want %d", player, byes[player], want)
+				}
+			}
+		})
+	}
+}
+
+func TestTournamentSeed(t *testing.T) {
+	lobby, err := NewLobby(RoomConfig{})
+	if err != nil {
+		t.Fatalf("NewLobby() error = %v", err)
+	}
+	tournaments, err := NewTournaments(lobby, "")
+	if err != nil {
+		t.Fatalf("NewTournaments() error = %v", err)
+	}
+
+	// cat win the rated game, ann and bob keep the default rating in the order of the config
+	lobby.Ratings.Update([]PlayerScore{{Name: "cat", Point: 10}, {Name: "dan", Point: 0}})
+
+	tournament, err := tournaments.Create(TournamentConfig{Players: []string{"ann", "bob", " cat ", "dan", "ann"}})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if want := []string{"cat", "ann", "bob", "dan"}; !reflect.DeepEqual(tournament.Players, want) {
+		t.Errorf("seed = %v, want %v", tournament.Players, want)
+	}
+	if want := []string{"cat-dan", "ann-bob"}; !reflect.DeepEqual(pairs(tournament.Rounds[0]), want) {
+		t.Errorf("first round = %v, want %v", pairs(tournament.Rounds[0]), want)
+	}
+}
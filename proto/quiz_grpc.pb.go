@@ -24,6 +24,20 @@ const _ = grpc.SupportPackageIsVersion7
 type QuizClient interface {
 	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*Message, error)
 	Stream(ctx context.Context, opts ...grpc.CallOption) (Quiz_StreamClient, error)
+	Spectate(ctx context.Context, in *SpectateRequest, opts ...grpc.CallOption) (Quiz_SpectateClient, error)
+	CreateRoom(ctx context.Context, in *CreateRoomRequest, opts ...grpc.CallOption) (*Room, error)
+	ListRooms(ctx context.Context, in *ListRoomsRequest, opts ...grpc.CallOption) (*ListRoomsResponse, error)
+	StartGame(ctx context.Context, in *RoomRequest, opts ...grpc.CallOption) (*Message, error)
+	GetState(ctx context.Context, in *RoomRequest, opts ...grpc.CallOption) (*GameState, error)
+	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
+	// FindMatch wait in the matchmaking queue until the 1v1 duel room is ready
+	FindMatch(ctx context.Context, in *FindMatchRequest, opts ...grpc.CallOption) (*Match, error)
+	CreateTournament(ctx context.Context, in *CreateTournamentRequest, opts ...grpc.CallOption) (*Tournament, error)
+	ListTournaments(ctx context.Context, in *ListTournamentsRequest, opts ...grpc.CallOption) (*ListTournamentsResponse, error)
+	// StartTournament create the room of the first round, the next round is started when the round is done
+	StartTournament(ctx context.Context, in *TournamentRequest, opts ...grpc.CallOption) (*Tournament, error)
+	// GetBracket return the bracket, the room of the match and the standings
+	GetBracket(ctx context.Context, in *TournamentRequest, opts ...grpc.CallOption) (*Tournament, error)
 }
 
 type quizClient struct {
@@ -74,12 +88,148 @@ func (x *quizStreamClient) Recv() (*StreamResponse, error) {
 	return m, nil
 }
 
+func (c *quizClient) Spectate(ctx context.Context, in *SpectateRequest, opts ...grpc.CallOption) (Quiz_SpectateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Quiz_ServiceDesc.Streams[1], "/quiz.Quiz/Spectate", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &quizSpectateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Quiz_SpectateClient interface {
+	Recv() (*StreamResponse, error)
+	grpc.ClientStream
+}
+
+type quizSpectateClient struct {
+	grpc.ClientStream
+}
+
+func (x *quizSpectateClient) Recv() (*StreamResponse, error) {
+	m := new(StreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *quizClient) CreateRoom(ctx context.Context, in *CreateRoomRequest, opts ...grpc.CallOption) (*Room, error) {
+	out := new(Room)
+	err := c.cc.Invoke(ctx, "/quiz.Quiz/CreateRoom", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizClient) ListRooms(ctx context.Context, in *ListRoomsRequest, opts ...grpc.CallOption) (*ListRoomsResponse, error) {
+	out := new(ListRoomsResponse)
+	err := c.cc.Invoke(ctx, "/quiz.Quiz/ListRooms", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizClient) StartGame(ctx context.Context, in *RoomRequest, opts ...grpc.CallOption) (*Message, error) {
+	out := new(Message)
+	err := c.cc.Invoke(ctx, "/quiz.Quiz/StartGame", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizClient) GetState(ctx context.Context, in *RoomRequest, opts ...grpc.CallOption) (*GameState, error) {
+	out := new(GameState)
+	err := c.cc.Invoke(ctx, "/quiz.Quiz/GetState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizClient) GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error) {
+	out := new(GetHistoryResponse)
+	err := c.cc.Invoke(ctx, "/quiz.Quiz/GetHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizClient) FindMatch(ctx context.Context, in *FindMatchRequest, opts ...grpc.CallOption) (*Match, error) {
+	out := new(Match)
+	err := c.cc.Invoke(ctx, "/quiz.Quiz/FindMatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizClient) CreateTournament(ctx context.Context, in *CreateTournamentRequest, opts ...grpc.CallOption) (*Tournament, error) {
+	out := new(Tournament)
+	err := c.cc.Invoke(ctx, "/quiz.Quiz/CreateTournament", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizClient) ListTournaments(ctx context.Context, in *ListTournamentsRequest, opts ...grpc.CallOption) (*ListTournamentsResponse, error) {
+	out := new(ListTournamentsResponse)
+	err := c.cc.Invoke(ctx, "/quiz.Quiz/ListTournaments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizClient) StartTournament(ctx context.Context, in *TournamentRequest, opts ...grpc.CallOption) (*Tournament, error) {
+	out := new(Tournament)
+	err := c.cc.Invoke(ctx, "/quiz.Quiz/StartTournament", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizClient) GetBracket(ctx context.Context, in *TournamentRequest, opts ...grpc.CallOption) (*Tournament, error) {
+	out := new(Tournament)
+	err := c.cc.Invoke(ctx, "/quiz.Quiz/GetBracket", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // QuizServer is the server API for Quiz service.
 // All implementations must embed UnimplementedQuizServer
 // for forward compatibility
 type QuizServer interface {
 	Register(context.Context, *RegisterRequest) (*Message, error)
 	Stream(Quiz_StreamServer) error
+	Spectate(*SpectateRequest, Quiz_SpectateServer) error
+	CreateRoom(context.Context, *CreateRoomRequest) (*Room, error)
+	ListRooms(context.Context, *ListRoomsRequest) (*ListRoomsResponse, error)
+	StartGame(context.Context, *RoomRequest) (*Message, error)
+	GetState(context.Context, *RoomRequest) (*GameState, error)
+	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
+	// FindMatch wait in the matchmaking queue until the 1v1 duel room is ready
+	FindMatch(context.Context, *FindMatchRequest) (*Match, error)
+	CreateTournament(context.Context, *CreateTournamentRequest) (*Tournament, error)
+	ListTournaments(context.Context, *ListTournamentsRequest) (*ListTournamentsResponse, error)
+	// StartTournament create the room of the first round, the next round is started when the round is done
+	StartTournament(context.Context, *TournamentRequest) (*Tournament, error)
+	// GetBracket return the bracket, the room of the match and the standings
+	GetBracket(context.Context, *TournamentRequest) (*Tournament, error)
 	mustEmbedUnimplementedQuizServer()
 }
 
@@ -93,6 +243,39 @@ func (UnimplementedQuizServer) Register(context.Context, *RegisterRequest) (*Mes
 func (UnimplementedQuizServer) Stream(Quiz_StreamServer) error {
 	return status.Errorf(codes.Unimplemented, "method Stream not implemented")
 }
+func (UnimplementedQuizServer) Spectate(*SpectateRequest, Quiz_SpectateServer) error {
+	return status.Errorf(codes.Unimplemented, "method Spectate not implemented")
+}
+func (UnimplementedQuizServer) CreateRoom(context.Context, *CreateRoomRequest) (*Room, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRoom not implemented")
+}
+func (UnimplementedQuizServer) ListRooms(context.Context, *ListRoomsRequest) (*ListRoomsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRooms not implemented")
+}
+func (UnimplementedQuizServer) StartGame(context.Context, *RoomRequest) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartGame not implemented")
+}
+func (UnimplementedQuizServer) GetState(context.Context, *RoomRequest) (*GameState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetState not implemented")
+}
+func (UnimplementedQuizServer) GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHistory not implemented")
+}
+func (UnimplementedQuizServer) FindMatch(context.Context, *FindMatchRequest) (*Match, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindMatch not implemented")
+}
+func (UnimplementedQuizServer) CreateTournament(context.Context, *CreateTournamentRequest) (*Tournament, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTournament not implemented")
+}
+func (UnimplementedQuizServer) ListTournaments(context.Context, *ListTournamentsRequest) (*ListTournamentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTournaments not implemented")
+}
+func (UnimplementedQuizServer) StartTournament(context.Context, *TournamentRequest) (*Tournament, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartTournament not implemented")
+}
+func (UnimplementedQuizServer) GetBracket(context.Context, *TournamentRequest) (*Tournament, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBracket not implemented")
+}
 func (UnimplementedQuizServer) mustEmbedUnimplementedQuizServer() {}
 
 // UnsafeQuizServer may be embedded to opt out of forward compatibility for this service.
@@ -150,6 +333,207 @@ func (x *quizStreamServer) Recv() (*Message, error) {
 	return m, nil
 }
 
+func _Quiz_Spectate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SpectateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QuizServer).Spectate(m, &quizSpectateServer{stream})
+}
+
+type Quiz_SpectateServer interface {
+	Send(*StreamResponse) error
+	grpc.ServerStream
+}
+
+type quizSpectateServer struct {
+	grpc.ServerStream
+}
+
+func (x *quizSpectateServer) Send(m *StreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Quiz_CreateRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServer).CreateRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/quiz.Quiz/CreateRoom",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServer).CreateRoom(ctx, req.(*CreateRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Quiz_ListRooms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoomsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServer).ListRooms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/quiz.Quiz/ListRooms",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServer).ListRooms(ctx, req.(*ListRoomsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Quiz_StartGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServer).StartGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/quiz.Quiz/StartGame",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServer).StartGame(ctx, req.(*RoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Quiz_GetState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServer).GetState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/quiz.Quiz/GetState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServer).GetState(ctx, req.(*RoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Quiz_GetHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServer).GetHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/quiz.Quiz/GetHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServer).GetHistory(ctx, req.(*GetHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Quiz_FindMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServer).FindMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/quiz.Quiz/FindMatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServer).FindMatch(ctx, req.(*FindMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Quiz_CreateTournament_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTournamentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServer).CreateTournament(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/quiz.Quiz/CreateTournament",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServer).CreateTournament(ctx, req.(*CreateTournamentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Quiz_ListTournaments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTournamentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServer).ListTournaments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/quiz.Quiz/ListTournaments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServer).ListTournaments(ctx, req.(*ListTournamentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Quiz_StartTournament_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TournamentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServer).StartTournament(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/quiz.Quiz/StartTournament",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServer).StartTournament(ctx, req.(*TournamentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Quiz_GetBracket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TournamentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServer).GetBracket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/quiz.Quiz/GetBracket",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServer).GetBracket(ctx, req.(*TournamentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Quiz_ServiceDesc is the grpc.ServiceDesc for Quiz service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -161,6 +545,46 @@ var Quiz_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Register",
 			Handler:    _Quiz_Register_Handler,
 		},
+		{
+			MethodName: "CreateRoom",
+			Handler:    _Quiz_CreateRoom_Handler,
+		},
+		{
+			MethodName: "ListRooms",
+			Handler:    _Quiz_ListRooms_Handler,
+		},
+		{
+			MethodName: "StartGame",
+			Handler:    _Quiz_StartGame_Handler,
+		},
+		{
+			MethodName: "GetState",
+			Handler:    _Quiz_GetState_Handler,
+		},
+		{
+			MethodName: "GetHistory",
+			Handler:    _Quiz_GetHistory_Handler,
+		},
+		{
+			MethodName: "FindMatch",
+			Handler:    _Quiz_FindMatch_Handler,
+		},
+		{
+			MethodName: "CreateTournament",
+			Handler:    _Quiz_CreateTournament_Handler,
+		},
+		{
+			MethodName: "ListTournaments",
+			Handler:    _Quiz_ListTournaments_Handler,
+		},
+		{
+			MethodName: "StartTournament",
+			Handler:    _Quiz_StartTournament_Handler,
+		},
+		{
+			MethodName: "GetBracket",
+			Handler:    _Quiz_GetBracket_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -169,6 +593,251 @@ var Quiz_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "Spectate",
+			Handler:       _Quiz_Spectate_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/quiz.proto",
+}
+
+// AdminClient is the client API for Admin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AdminClient interface {
+	// GetBank return the status of the question bank, errors is the reason of the last failed reload
+	GetBank(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*BankStatus, error)
+	// ReloadBank load the question bank now. The failed reload keep the old bank and return the errors in the status
+	ReloadBank(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*BankStatus, error)
+	// ControlRound pause, resume, skip or extend the round, or start the next round in review
+	ControlRound(ctx context.Context, in *ControlRoundRequest, opts ...grpc.CallOption) (*GameState, error)
+	// AddBots join the bots to the room before the game is started, the bot is tagged in the leaderboard and not rated
+	AddBots(ctx context.Context, in *AddBotsRequest, opts ...grpc.CallOption) (*AddBotsResponse, error)
+	// ExportResult return the report of the last finished game of the room, the csv report is a file for each table
+	ExportResult(ctx context.Context, in *ExportResultRequest, opts ...grpc.CallOption) (*ExportResultResponse, error)
+}
+
+type adminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminClient(cc grpc.ClientConnInterface) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) GetBank(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*BankStatus, error) {
+	out := new(BankStatus)
+	err := c.cc.Invoke(ctx, "/quiz.Admin/GetBank", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ReloadBank(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*BankStatus, error) {
+	out := new(BankStatus)
+	err := c.cc.Invoke(ctx, "/quiz.Admin/ReloadBank", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ControlRound(ctx context.Context, in *ControlRoundRequest, opts ...grpc.CallOption) (*GameState, error) {
+	out := new(GameState)
+	err := c.cc.Invoke(ctx, "/quiz.Admin/ControlRound", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) AddBots(ctx context.Context, in *AddBotsRequest, opts ...grpc.CallOption) (*AddBotsResponse, error) {
+	out := new(AddBotsResponse)
+	err := c.cc.Invoke(ctx, "/quiz.Admin/AddBots", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ExportResult(ctx context.Context, in *ExportResultRequest, opts ...grpc.CallOption) (*ExportResultResponse, error) {
+	out := new(ExportResultResponse)
+	err := c.cc.Invoke(ctx, "/quiz.Admin/ExportResult", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServer is the server API for Admin service.
+// All implementations must embed UnimplementedAdminServer
+// for forward compatibility
+type AdminServer interface {
+	// GetBank return the status of the question bank, errors is the reason of the last failed reload
+	GetBank(context.Context, *AdminRequest) (*BankStatus, error)
+	// ReloadBank load the question bank now. The failed reload keep the old bank and return the errors in the status
+	ReloadBank(context.Context, *AdminRequest) (*BankStatus, error)
+	// ControlRound pause, resume, skip or extend the round, or start the next round in review
+	ControlRound(context.Context, *ControlRoundRequest) (*GameState, error)
+	// AddBots join the bots to the room before the game is started, the bot is tagged in the leaderboard and not rated
+	AddBots(context.Context, *AddBotsRequest) (*AddBotsResponse, error)
+	// ExportResult return the report of the last finished game of the room, the csv report is a file for each table
+	ExportResult(context.Context, *ExportResultRequest) (*ExportResultResponse, error)
+	mustEmbedUnimplementedAdminServer()
+}
+
+// UnimplementedAdminServer must be embedded to have forward compatible implementations.
+type UnimplementedAdminServer struct {
+}
+
+func (UnimplementedAdminServer) GetBank(context.Context, *AdminRequest) (*BankStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBank not implemented")
+}
+func (UnimplementedAdminServer) ReloadBank(context.Context, *AdminRequest) (*BankStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadBank not implemented")
+}
+func (UnimplementedAdminServer) ControlRound(context.Context, *ControlRoundRequest) (*GameState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ControlRound not implemented")
+}
+func (UnimplementedAdminServer) AddBots(context.Context, *AddBotsRequest) (*AddBotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddBots not implemented")
+}
+func (UnimplementedAdminServer) ExportResult(context.Context, *ExportResultRequest) (*ExportResultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportResult not implemented")
+}
+func (UnimplementedAdminServer) mustEmbedUnimplementedAdminServer() {}
+
+// UnsafeAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServer will
+// result in compilation errors.
+type UnsafeAdminServer interface {
+	mustEmbedUnimplementedAdminServer()
+}
+
+func RegisterAdminServer(s grpc.ServiceRegistrar, srv AdminServer) {
+	s.RegisterService(&Admin_ServiceDesc, srv)
+}
+
+func _Admin_GetBank_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetBank(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/quiz.Admin/GetBank",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetBank(ctx, req.(*AdminRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ReloadBank_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ReloadBank(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/quiz.Admin/ReloadBank",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ReloadBank(ctx, req.(*AdminRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ControlRound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ControlRoundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ControlRound(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/quiz.Admin/ControlRound",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ControlRound(ctx, req.(*ControlRoundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_AddBots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddBotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).AddBots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/quiz.Admin/AddBots",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).AddBots(ctx, req.(*AddBotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ExportResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ExportResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/quiz.Admin/ExportResult",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ExportResult(ctx, req.(*ExportResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Admin_ServiceDesc is the grpc.ServiceDesc for Admin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Admin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "quiz.Admin",
+	HandlerType: (*AdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBank",
+			Handler:    _Admin_GetBank_Handler,
+		},
+		{
+			MethodName: "ReloadBank",
+			Handler:    _Admin_ReloadBank_Handler,
+		},
+		{
+			MethodName: "ControlRound",
+			Handler:    _Admin_ControlRound_Handler,
+		},
+		{
+			MethodName: "AddBots",
+			Handler:    _Admin_AddBots_Handler,
+		},
+		{
+			MethodName: "ExportResult",
+			Handler:    _Admin_ExportResult_Handler,
+		},
 	},
+	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/quiz.proto",
 }